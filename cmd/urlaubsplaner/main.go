@@ -0,0 +1,145 @@
+// Command urlaubsplaner wires config, storage, auth, and the HTTP router
+// together and starts the server.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/api"
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/authz"
+	"github.com/speze88/vacationplanner-go/pkg/mail"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+// newMailer returns an SMTP-backed Mailer if SMTP_HOST is set, or a
+// stdout Mailer for local development otherwise.
+func newMailer() mail.Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return mail.NewStdoutMailer()
+	}
+	return mail.NewSMTPMailer(mail.SMTPConfig{
+		Host: host,
+		Port: env("SMTP_PORT", "587"),
+		User: os.Getenv("SMTP_USER"),
+		Pass: os.Getenv("SMTP_PASS"),
+		From: env("SMTP_FROM", "noreply@urlaubsplaner.local"),
+	})
+}
+
+func env(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("Invalid duration for %s: %v", key, err)
+	}
+	return d
+}
+
+func seedAdminUser(users *storage.UserRepo) {
+	username := env("URLAUBSPLANER_ADMIN_USER", "admin")
+	password := env("URLAUBSPLANER_ADMIN_PASS", "changeme")
+
+	count, err := users.Count()
+	if err != nil {
+		log.Fatalf("Cannot count users: %v", err)
+	}
+	if count > 0 {
+		return
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		log.Fatalf("Cannot hash password: %v", err)
+	}
+
+	if _, err := users.Create(username, hash, username, "BY", 30, true); err != nil {
+		log.Fatalf("Cannot create admin user: %v", err)
+	}
+
+	log.Printf("Created admin user '%s'", username)
+}
+
+func main() {
+	port := env("URLAUBSPLANER_PORT", "8080")
+	dbPath := env("URLAUBSPLANER_DB_PATH", "./data/urlaubsplaner.db")
+	maxIdle := envDuration("URLAUBSPLANER_SESSION_MAX_IDLE", 30*24*time.Hour)
+	maxLifetime := envDuration("URLAUBSPLANER_SESSION_MAX_LIFETIME", 90*24*time.Hour)
+
+	db, err := storage.Open(dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	users := storage.NewUserRepo(db)
+	seedAdminUser(users)
+
+	sessions, err := auth.NewSQLStore(storage.NewSessionRepo(db), maxIdle, maxLifetime)
+	if err != nil {
+		log.Fatalf("Cannot load sessions: %v", err)
+	}
+
+	groups := storage.NewGroupRepo(db)
+
+	srv := &api.Server{
+		Users:          users,
+		Absences:       storage.NewAbsenceRepo(db),
+		Quotas:         storage.NewQuotaRepo(db),
+		Groups:         groups,
+		Recovery:       storage.NewRecoveryCodeRepo(db),
+		PasswordResets: storage.NewPasswordResetRepo(db),
+		Sessions:       sessions,
+		Challenges:     auth.NewChallengeStore(5 * time.Minute),
+		Policy:         authz.NewPolicy(groups),
+		Mailer:         newMailer(),
+		StaticDir:      ".",
+		CookieMaxAge:   maxIdle,
+	}
+
+	httpServer := &http.Server{
+		Addr:    ":" + port,
+		Handler: api.NewRouter(srv),
+	}
+
+	go func() {
+		log.Printf("Urlaubsplaner listening on :%s", port)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("ListenAndServe: %v", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	log.Println("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Printf("HTTP shutdown: %v", err)
+	}
+	if err := sessions.Shutdown(ctx); err != nil {
+		log.Printf("Session store shutdown: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		log.Printf("Database close: %v", err)
+	}
+}
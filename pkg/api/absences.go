@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/holidays"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+var validAbsenceTypes = map[string]bool{"UR": true, "UR/2": true, "SUR": true, "UUR": true}
+
+// absenceEntryOut is AbsenceEntry plus whether the day counts toward the
+// yearly quota: a UR on a weekend or public holiday doesn't, since it was
+// never a working day to begin with.
+type absenceEntryOut struct {
+	Type              string  `json:"type"`
+	Status            *string `json:"status,omitempty"`
+	CountsTowardQuota bool    `json:"countsTowardQuota"`
+}
+
+func (s *Server) handleGetAbsences(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+	year := r.URL.Query().Get("year")
+
+	entries, err := s.Absences.ListByUser(sess.UserID, year)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	user, err := s.Users.ByID(sess.UserID)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, withQuotaAnnotations(entries, user.State))
+}
+
+// withQuotaAnnotations adds CountsTowardQuota to each entry: false only for
+// a full-day UR that falls on a weekend or public holiday in state.
+func withQuotaAnnotations(entries map[string]storage.AbsenceEntry, state string) map[string]absenceEntryOut {
+	result := make(map[string]absenceEntryOut, len(entries))
+	for date, entry := range entries {
+		countsTowardQuota := true
+		if entry.Type == "UR" {
+			if t, err := time.Parse("2006-01-02", date); err == nil {
+				countsTowardQuota = holidays.IsWorkingDay(t, state)
+			}
+		}
+		result[date] = absenceEntryOut{Type: entry.Type, Status: entry.Status, CountsTowardQuota: countsTowardQuota}
+	}
+	return result
+}
+
+func (s *Server) handlePutAbsences(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+
+	var req struct {
+		Dates map[string]string `json:"dates"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	for date, typ := range req.Dates {
+		if !validAbsenceTypes[typ] {
+			httpx.Error(w, 400, fmt.Sprintf("Ungültiger Typ: %s", typ))
+			return
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			httpx.Error(w, 400, fmt.Sprintf("Ungültiges Datum: %s", date))
+			return
+		}
+	}
+
+	status := "pending"
+	if sess.IsAdmin {
+		status = "approved"
+	}
+
+	count, err := s.Absences.PutMany(sess.UserID, req.Dates, status)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{"ok": true, "count": count})
+}
+
+func (s *Server) handleDeleteAbsences(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+
+	var req struct {
+		Dates []string `json:"dates"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	count, err := s.Absences.DeleteMany(sess.UserID, req.Dates)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{"ok": true, "count": count})
+}
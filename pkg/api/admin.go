@@ -0,0 +1,144 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+func (s *Server) handleAdminGetUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := s.Users.List()
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	result := make([]map[string]any, 0, len(users))
+	for _, u := range users {
+		result = append(result, map[string]any{
+			"id":           u.ID,
+			"username":     u.Username,
+			"displayName":  u.DisplayName,
+			"state":        u.State,
+			"defaultQuota": u.DefaultQuota,
+			"isAdmin":      u.IsAdmin,
+			"createdAt":    u.CreatedAt,
+		})
+	}
+
+	httpx.JSON(w, 200, result)
+}
+
+func (s *Server) handleAdminCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username    string  `json:"username"`
+		Password    string  `json:"password"`
+		DisplayName string  `json:"displayName"`
+		IsAdmin     bool    `json:"isAdmin"`
+		State       string  `json:"state"`
+		Quota       float64 `json:"defaultQuota"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" || req.Password == "" {
+		httpx.Error(w, 400, "Benutzername und Passwort erforderlich")
+		return
+	}
+	if req.DisplayName == "" {
+		req.DisplayName = req.Username
+	}
+	if req.State == "" {
+		req.State = "BY"
+	}
+	if req.Quota == 0 {
+		req.Quota = 30
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		httpx.Error(w, 500, "Passwort-Hashing fehlgeschlagen")
+		return
+	}
+
+	id, err := s.Users.Create(req.Username, hash, req.DisplayName, req.State, req.Quota, req.IsAdmin)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			httpx.Error(w, 409, "Benutzername bereits vergeben")
+			return
+		}
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 201, map[string]any{"id": id, "username": req.Username})
+}
+
+func (s *Server) handleAdminDeleteUser(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 4 {
+		httpx.Error(w, 400, "User-ID fehlt")
+		return
+	}
+	idStr := parts[len(parts)-1]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		httpx.Error(w, 400, "Ungültige User-ID")
+		return
+	}
+
+	sess := auth.SessionFromContext(r.Context())
+	if id == sess.UserID {
+		httpx.Error(w, 400, "Eigenen Account kann man nicht löschen")
+		return
+	}
+
+	if err := s.Users.Delete(id); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+func (s *Server) handleAdminResetPassword(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	// /api/admin/users/{id}/password
+	if len(parts) < 5 {
+		httpx.Error(w, 400, "User-ID fehlt")
+		return
+	}
+	idStr := parts[len(parts)-2]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		httpx.Error(w, 400, "Ungültige User-ID")
+		return
+	}
+
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil || req.Password == "" {
+		httpx.Error(w, 400, "Passwort erforderlich")
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		httpx.Error(w, 500, "Passwort-Hashing fehlgeschlagen")
+		return
+	}
+
+	if err := s.Users.UpdatePassword(id, hash); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	s.Sessions.DeleteByUser(id)
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
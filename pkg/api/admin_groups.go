@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+func (s *Server) handleAdminGroups(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		s.handleAdminListGroups(w, r)
+	case "POST":
+		s.handleAdminCreateGroup(w, r)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) handleAdminListGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := s.Groups.List()
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	result := make([]map[string]any, 0, len(groups))
+	for _, g := range groups {
+		members, err := s.Groups.MembersOf(g.ID)
+		if err != nil {
+			httpx.Error(w, 500, "Datenbankfehler")
+			return
+		}
+		memberList := make([]map[string]any, 0, len(members))
+		for _, m := range members {
+			memberList = append(memberList, map[string]any{
+				"userId":   m.UserID,
+				"username": m.Username,
+				"role":     m.Role,
+			})
+		}
+		result = append(result, map[string]any{"id": g.ID, "name": g.Name, "members": memberList})
+	}
+
+	httpx.JSON(w, 200, result)
+}
+
+func (s *Server) handleAdminCreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		httpx.Error(w, 400, "Name erforderlich")
+		return
+	}
+
+	id, err := s.Groups.Create(req.Name)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			httpx.Error(w, 409, "Gruppenname bereits vergeben")
+			return
+		}
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 201, map[string]any{"id": id, "name": req.Name})
+}
+
+// handleAdminGroupMembers handles POST/DELETE /api/admin/groups/{id}/members.
+func (s *Server) handleAdminGroupMembers(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 5 {
+		httpx.Error(w, 400, "Gruppen-ID fehlt")
+		return
+	}
+	groupID, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		httpx.Error(w, 400, "Ungültige Gruppen-ID")
+		return
+	}
+
+	switch r.Method {
+	case "POST":
+		var req struct {
+			UserID int64  `json:"userId"`
+			Role   string `json:"role"`
+		}
+		if err := httpx.ReadJSON(r, &req); err != nil {
+			httpx.Error(w, 400, "Ungültige Anfrage")
+			return
+		}
+		if req.Role != "member" && req.Role != "lead" {
+			httpx.Error(w, 400, "Ungültige Rolle")
+			return
+		}
+		if err := s.Groups.AddMember(groupID, req.UserID, req.Role); err != nil {
+			httpx.Error(w, 500, "Datenbankfehler")
+			return
+		}
+		httpx.JSON(w, 200, map[string]any{"ok": true})
+	case "DELETE":
+		var req struct {
+			UserID int64 `json:"userId"`
+		}
+		if err := httpx.ReadJSON(r, &req); err != nil {
+			httpx.Error(w, 400, "Ungültige Anfrage")
+			return
+		}
+		if err := s.Groups.RemoveMember(groupID, req.UserID); err != nil {
+			httpx.Error(w, 500, "Datenbankfehler")
+			return
+		}
+		httpx.JSON(w, 200, map[string]any{"ok": true})
+	default:
+		methodNotAllowed(w)
+	}
+}
@@ -0,0 +1,627 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/authz"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+	"github.com/speze88/vacationplanner-go/pkg/totp"
+)
+
+func newTestServer(t *testing.T) (*Server, *storage.User) {
+	t.Helper()
+	db, err := storage.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	users := storage.NewUserRepo(db)
+	hash, err := auth.HashPassword("hunter2")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	id, err := users.Create("alice", hash, "Alice", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	user, err := users.ByID(id)
+	if err != nil {
+		t.Fatalf("ByID: %v", err)
+	}
+
+	groups := storage.NewGroupRepo(db)
+
+	srv := &Server{
+		Users:          users,
+		Absences:       storage.NewAbsenceRepo(db),
+		Quotas:         storage.NewQuotaRepo(db),
+		Groups:         groups,
+		Recovery:       storage.NewRecoveryCodeRepo(db),
+		PasswordResets: storage.NewPasswordResetRepo(db),
+		Sessions:       auth.NewMemoryStore(),
+		Challenges:     auth.NewChallengeStore(5 * time.Minute),
+		Policy:         authz.NewPolicy(groups),
+		Mailer:         &fakeMailer{},
+		StaticDir:      ".",
+		CookieMaxAge:   30 * 24 * time.Hour,
+	}
+	return srv, user
+}
+
+// fakeMailer records the last message it was asked to send, so tests can
+// recover a token that would otherwise only be emailed to the user.
+type fakeMailer struct {
+	lastTo      string
+	lastSubject string
+	lastBody    string
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	m.lastTo, m.lastSubject, m.lastBody = to, subject, body
+	return nil
+}
+
+func TestHandleLogin(t *testing.T) {
+	cases := []struct {
+		name       string
+		username   string
+		password   string
+		wantStatus int
+		wantCookie bool
+	}{
+		{"correct credentials", "alice", "hunter2", 200, true},
+		{"wrong password", "alice", "wrong", 401, false},
+		{"unknown user", "ghost", "hunter2", 401, false},
+		{"missing password", "alice", "", 400, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, _ := newTestServer(t)
+
+			body, _ := json.Marshal(map[string]string{"username": tc.username, "password": tc.password})
+			req := httptest.NewRequest("POST", "/api/login", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			srv.handleLogin(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+
+			hasCookie := false
+			for _, c := range w.Result().Cookies() {
+				if c.Name == auth.CookieName {
+					hasCookie = true
+				}
+			}
+			if hasCookie != tc.wantCookie {
+				t.Errorf("has session cookie = %v, want %v", hasCookie, tc.wantCookie)
+			}
+		})
+	}
+}
+
+func TestRequireAuthRejectsAnonymous(t *testing.T) {
+	srv, _ := newTestServer(t)
+	mux := NewRouter(srv)
+
+	req := httptest.NewRequest("GET", "/api/me", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+// TestTOTPEnrollAndLogin exercises the full 2FA flow: setup, verify, a
+// login that's deferred pending a second factor, and completing it with
+// both a TOTP code and a recovery code.
+func TestTOTPEnrollAndLogin(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	token := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	authedReq := func(method, path string, body []byte) *http.Request {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+		return req
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, authedReq("POST", "/api/2fa/setup", nil))
+	if w.Code != 200 {
+		t.Fatalf("setup status = %d, want 200", w.Code)
+	}
+	var setupResp struct {
+		Secret        string   `json:"secret"`
+		OTPAuth       string   `json:"otpauth"`
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &setupResp); err != nil {
+		t.Fatalf("decode setup response: %v", err)
+	}
+	if setupResp.Secret == "" || len(setupResp.RecoveryCodes) != recoveryCodeCount {
+		t.Fatalf("setup response = %+v, want a secret and %d recovery codes", setupResp, recoveryCodeCount)
+	}
+
+	code, err := totp.GenerateCode(setupResp.Secret)
+	if err != nil {
+		t.Fatalf("totp.GenerateCode: %v", err)
+	}
+	verifyBody, _ := json.Marshal(map[string]string{"code": code})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, authedReq("POST", "/api/2fa/verify", verifyBody))
+	if w.Code != 200 {
+		t.Fatalf("verify status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	// Logging in now must be deferred behind the second factor.
+	loginBody, _ := json.Marshal(map[string]string{"username": user.Username, "password": "hunter2"})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login", bytes.NewReader(loginBody)))
+	if w.Code != 200 {
+		t.Fatalf("login status = %d, want 200", w.Code)
+	}
+	var loginResp struct {
+		TwoFactorRequired bool   `json:"twoFactorRequired"`
+		Challenge         string `json:"challenge"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &loginResp); err != nil {
+		t.Fatalf("decode login response: %v", err)
+	}
+	if !loginResp.TwoFactorRequired || loginResp.Challenge == "" {
+		t.Fatalf("login response = %+v, want twoFactorRequired with a challenge", loginResp)
+	}
+	for _, c := range w.Result().Cookies() {
+		if c.Name == auth.CookieName {
+			t.Error("login set a session cookie before the second factor was verified")
+		}
+	}
+
+	code, err = totp.GenerateCode(setupResp.Secret)
+	if err != nil {
+		t.Fatalf("totp.GenerateCode: %v", err)
+	}
+	completeBody, _ := json.Marshal(map[string]string{"challenge": loginResp.Challenge, "code": code})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login/2fa", bytes.NewReader(completeBody)))
+	if w.Code != 200 {
+		t.Fatalf("login/2fa status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+	hasCookie := false
+	for _, c := range w.Result().Cookies() {
+		if c.Name == auth.CookieName {
+			hasCookie = true
+		}
+	}
+	if !hasCookie {
+		t.Error("login/2fa did not set a session cookie")
+	}
+
+	// A recovery code works as a fallback for a second, independent login.
+	loginBody, _ = json.Marshal(map[string]string{"username": user.Username, "password": "hunter2"})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login", bytes.NewReader(loginBody)))
+	if err := json.Unmarshal(w.Body.Bytes(), &loginResp); err != nil {
+		t.Fatalf("decode login response: %v", err)
+	}
+
+	recoveryBody, _ := json.Marshal(map[string]string{"challenge": loginResp.Challenge, "code": setupResp.RecoveryCodes[0]})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login/2fa", bytes.NewReader(recoveryBody)))
+	if w.Code != 200 {
+		t.Fatalf("login/2fa with recovery code status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	// The same recovery code cannot be reused.
+	loginBody, _ = json.Marshal(map[string]string{"username": user.Username, "password": "hunter2"})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login", bytes.NewReader(loginBody)))
+	if err := json.Unmarshal(w.Body.Bytes(), &loginResp); err != nil {
+		t.Fatalf("decode login response: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login/2fa", bytes.NewReader(recoveryBody)))
+	if w.Code != 401 {
+		t.Errorf("reused recovery code status = %d, want 401", w.Code)
+	}
+}
+
+// TestPasswordResetFlow exercises forgot -> reset end to end: the mailed
+// code changes the password, invalidates existing sessions, and cannot be
+// reused.
+func TestPasswordResetFlow(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+	mailer := srv.Mailer.(*fakeMailer)
+
+	oldToken := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+
+	forgotBody, _ := json.Marshal(map[string]string{"username": user.Username})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/password/forgot", bytes.NewReader(forgotBody)))
+	if w.Code != 200 {
+		t.Fatalf("forgot status = %d, want 200", w.Code)
+	}
+	if mailer.lastTo != user.Username {
+		t.Fatalf("mailer.lastTo = %q, want %q", mailer.lastTo, user.Username)
+	}
+
+	const prefix = "Code zum Zurücksetzen deines Passworts: "
+	idx := strings.Index(mailer.lastBody, prefix)
+	if idx < 0 {
+		t.Fatalf("mail body = %q, want it to contain the reset code", mailer.lastBody)
+	}
+	token := strings.SplitN(mailer.lastBody[idx+len(prefix):], "\n", 2)[0]
+
+	resetBody, _ := json.Marshal(map[string]string{"token": token, "newPassword": "newpass123"})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/password/reset", bytes.NewReader(resetBody)))
+	if w.Code != 200 {
+		t.Fatalf("reset status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	if _, ok := srv.Sessions.Get(oldToken); ok {
+		t.Error("session still valid after password reset, want it invalidated")
+	}
+
+	loginBody, _ := json.Marshal(map[string]string{"username": user.Username, "password": "hunter2"})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login", bytes.NewReader(loginBody)))
+	if w.Code != 401 {
+		t.Errorf("login with old password status = %d, want 401", w.Code)
+	}
+
+	loginBody, _ = json.Marshal(map[string]string{"username": user.Username, "password": "newpass123"})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/login", bytes.NewReader(loginBody)))
+	if w.Code != 200 {
+		t.Errorf("login with new password status = %d, want 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/password/reset", bytes.NewReader(resetBody)))
+	if w.Code != 400 {
+		t.Errorf("reused reset token status = %d, want 400", w.Code)
+	}
+}
+
+// TestAdminResetPasswordInvalidatesExistingSessions ensures an admin forcing
+// a password reset on another user's account (e.g. after a compromise or
+// offboarding) kicks out any session that user already holds, same as
+// self-service reset does.
+func TestAdminResetPasswordInvalidatesExistingSessions(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	adminHash, err := auth.HashPassword("adminpass")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	adminID, err := srv.Users.Create("admin", adminHash, "Admin", "BY", 30, true)
+	if err != nil {
+		t.Fatalf("Create admin: %v", err)
+	}
+	adminToken := srv.Sessions.Create(adminID, "admin", true)
+
+	oldToken := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+
+	body, _ := json.Marshal(map[string]string{"password": "newpass123"})
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/api/admin/users/%d/password", user.ID), bytes.NewReader(body))
+	req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: adminToken})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Fatalf("admin reset status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	if _, ok := srv.Sessions.Get(oldToken); ok {
+		t.Error("session still valid after admin password reset, want it invalidated")
+	}
+}
+
+// TestCalendarTokenRotateAndSubscribe exercises rotating a calendar token
+// and then fetching the iCalendar feed with it, with no session cookie.
+func TestCalendarTokenRotateAndSubscribe(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	token := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	req := httptest.NewRequest("POST", "/api/settings/calendar-token/rotate", nil)
+	req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Fatalf("rotate status = %d, want 200", w.Code)
+	}
+
+	var rotateResp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &rotateResp); err != nil {
+		t.Fatalf("decode rotate response: %v", err)
+	}
+	if rotateResp.Token == "" {
+		t.Fatal("rotate response has no token")
+	}
+
+	if _, err := srv.Absences.PutMany(user.ID, map[string]string{"2025-06-02": "UR"}, "approved"); err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/ical/"+rotateResp.Token+".ics", nil))
+	if w.Code != 200 {
+		t.Fatalf("ical feed status = %d, want 200", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "BEGIN:VEVENT") {
+		t.Errorf("ical feed body = %q, want a VEVENT for the absence", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/ical/not-a-real-token.ics", nil))
+	if w.Code != 404 {
+		t.Errorf("ical feed with unknown token status = %d, want 404", w.Code)
+	}
+}
+
+// TestICalFeedExcludesRejectedAndMarksPendingTentative ensures external
+// calendar subscribers never see a declined absence as confirmed leave, and
+// that an absence still awaiting lead approval is marked tentative rather
+// than confirmed.
+func TestICalFeedExcludesRejectedAndMarksPendingTentative(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	token := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	rotateReq := httptest.NewRequest("POST", "/api/settings/calendar-token/rotate", nil)
+	rotateReq.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, rotateReq)
+	var rotateResp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &rotateResp); err != nil {
+		t.Fatalf("decode rotate response: %v", err)
+	}
+
+	if _, err := srv.Absences.PutMany(user.ID, map[string]string{"2025-07-01": "UR"}, "approved"); err != nil {
+		t.Fatalf("PutMany approved: %v", err)
+	}
+	if _, err := srv.Absences.PutMany(user.ID, map[string]string{"2025-07-10": "UR"}, "pending"); err != nil {
+		t.Fatalf("PutMany pending: %v", err)
+	}
+	if _, err := srv.Absences.PutMany(user.ID, map[string]string{"2025-07-20": "UR"}, "rejected"); err != nil {
+		t.Fatalf("PutMany rejected: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/ical/"+rotateResp.Token+".ics", nil))
+	if w.Code != 200 {
+		t.Fatalf("ical feed status = %d, want 200", w.Code)
+	}
+	body := w.Body.String()
+	if strings.Count(body, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("feed = %q, want 2 VEVENTs (rejected absence excluded)", body)
+	}
+	if strings.Contains(body, "DTSTART;VALUE=DATE:20250720") {
+		t.Error("rejected absence was not excluded from the feed")
+	}
+	if !strings.Contains(body, "STATUS:TENTATIVE") {
+		t.Error("pending absence should be STATUS:TENTATIVE")
+	}
+	if !strings.Contains(body, "STATUS:CONFIRMED") {
+		t.Error("approved absence should be STATUS:CONFIRMED")
+	}
+}
+
+func TestAdminUserAbsencesICS(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	adminHash, err := auth.HashPassword("adminpass")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	adminID, err := srv.Users.Create("admin", adminHash, "Admin", "BY", 30, true)
+	if err != nil {
+		t.Fatalf("Create admin: %v", err)
+	}
+	adminToken := srv.Sessions.Create(adminID, "admin", true)
+
+	if _, err := srv.Absences.PutMany(user.ID, map[string]string{"2025-06-02": "UR"}, "approved"); err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/admin/users/%d/absences.ics", user.ID), nil)
+	req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: adminToken})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Fatalf("admin ics status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "BEGIN:VEVENT") {
+		t.Errorf("admin ics body = %q, want a VEVENT", w.Body.String())
+	}
+}
+
+func TestHandleGetAbsencesMarksHolidayAndWeekendURAsNotCountingTowardQuota(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	if err := srv.Users.UpdateSettings(user.ID, strPtr("BY"), nil, nil); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+
+	dates := map[string]string{
+		"2025-06-17": "UR", // regular Tuesday, counts
+		"2025-06-21": "UR", // Saturday, doesn't count
+		"2025-10-03": "UR", // Tag der Deutschen Einheit, doesn't count
+	}
+	if _, err := srv.Absences.PutMany(user.ID, dates, "approved"); err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+
+	token := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	req := httptest.NewRequest("GET", "/api/absences?year=2025", nil)
+	req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Fatalf("status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	var got map[string]struct {
+		Type              string `json:"type"`
+		CountsTowardQuota bool   `json:"countsTowardQuota"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	if !got["2025-06-17"].CountsTowardQuota {
+		t.Error("regular weekday UR should count toward quota")
+	}
+	if got["2025-06-21"].CountsTowardQuota {
+		t.Error("weekend UR should not count toward quota")
+	}
+	if got["2025-10-03"].CountsTowardQuota {
+		t.Error("public holiday UR should not count toward quota")
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestHandleGetHolidaysReturnsCallersStateHolidays(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	if err := srv.Users.UpdateSettings(user.ID, strPtr("SL"), nil, nil); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+
+	token := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	req := httptest.NewRequest("GET", "/api/holidays?year=2025", nil)
+	req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Fatalf("status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got["2025-08-15"] != "Mariä Himmelfahrt" {
+		t.Errorf("holidays[2025-08-15] = %q, want Mariä Himmelfahrt (SL-specific)", got["2025-08-15"])
+	}
+	if got["2025-01-01"] != "Neujahr" {
+		t.Errorf("holidays[2025-01-01] = %q, want Neujahr", got["2025-01-01"])
+	}
+}
+
+// TestTeamAbsenceActionRejectsSelfApproval verifies that a plain member
+// (not a team lead or admin) cannot approve their own pending absence by
+// calling the team endpoint directly.
+func TestTeamAbsenceActionRejectsSelfApproval(t *testing.T) {
+	srv, user := newTestServer(t)
+	mux := NewRouter(srv)
+
+	if _, err := srv.Absences.PutMany(user.ID, map[string]string{"2025-06-02": "UR"}, "pending"); err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+
+	token := srv.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/team/absences/%d/2025-06-02/approve", user.ID), nil)
+	req.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != 403 {
+		t.Fatalf("self-approve status = %d, want 403", w.Code)
+	}
+
+	entries, err := srv.Absences.ListByUser(user.ID, "2025")
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if got := entries["2025-06-02"].Status; got == nil || *got != "pending" {
+		t.Errorf("absence status = %v, want still pending", got)
+	}
+}
+
+// TestTeamAbsenceActionAllowsLeadApprovingMember verifies that a team lead
+// can approve a team member's pending absence but still cannot approve
+// their own.
+func TestTeamAbsenceActionAllowsLeadApprovingMember(t *testing.T) {
+	srv, lead := newTestServer(t)
+	mux := NewRouter(srv)
+
+	memberHash, err := auth.HashPassword("hunter2")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	memberID, err := srv.Users.Create("bob", memberHash, "Bob", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create member: %v", err)
+	}
+
+	groupID, err := srv.Groups.Create("Platform Team")
+	if err != nil {
+		t.Fatalf("Create group: %v", err)
+	}
+	if err := srv.Groups.AddMember(groupID, lead.ID, "lead"); err != nil {
+		t.Fatalf("AddMember(lead): %v", err)
+	}
+	if err := srv.Groups.AddMember(groupID, memberID, "member"); err != nil {
+		t.Fatalf("AddMember(member): %v", err)
+	}
+
+	if _, err := srv.Absences.PutMany(memberID, map[string]string{"2025-06-02": "UR"}, "pending"); err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+	if _, err := srv.Absences.PutMany(lead.ID, map[string]string{"2025-06-03": "UR"}, "pending"); err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+
+	token := srv.Sessions.Create(lead.ID, lead.Username, lead.IsAdmin)
+	approveMember := httptest.NewRequest("POST", fmt.Sprintf("/api/team/absences/%d/2025-06-02/approve", memberID), nil)
+	approveMember.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, approveMember)
+	if w.Code != 200 {
+		t.Fatalf("lead approving member status = %d, want 200, body %s", w.Code, w.Body.String())
+	}
+
+	approveSelf := httptest.NewRequest("POST", fmt.Sprintf("/api/team/absences/%d/2025-06-03/approve", lead.ID), nil)
+	approveSelf.AddCookie(&http.Cookie{Name: auth.CookieName, Value: token})
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, approveSelf)
+	if w.Code != 403 {
+		t.Fatalf("lead self-approve status = %d, want 403", w.Code)
+	}
+}
+
+func TestForgotPasswordDoesNotLeakUnknownUsers(t *testing.T) {
+	srv, _ := newTestServer(t)
+	mux := NewRouter(srv)
+
+	forgotBody, _ := json.Marshal(map[string]string{"username": "ghost"})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/password/forgot", bytes.NewReader(forgotBody)))
+	if w.Code != 200 {
+		t.Errorf("forgot status for unknown user = %d, want 200", w.Code)
+	}
+}
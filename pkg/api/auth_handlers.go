@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+
+	var req struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" || req.Password == "" {
+		httpx.Error(w, 400, "Benutzername und Passwort erforderlich")
+		return
+	}
+
+	user, err := s.Users.ByUsername(req.Username)
+	if err != nil {
+		httpx.Error(w, 401, "Ungültige Anmeldedaten")
+		return
+	}
+
+	if err := auth.CheckPassword(user.PasswordHash, req.Password); err != nil {
+		httpx.Error(w, 401, "Ungültige Anmeldedaten")
+		return
+	}
+
+	if user.TOTPEnabled {
+		challenge := s.Challenges.Create(user.ID)
+		httpx.JSON(w, 200, map[string]any{"twoFactorRequired": true, "challenge": challenge})
+		return
+	}
+
+	s.issueSession(w, user)
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+// issueSession creates a session for user and sets it as the session cookie.
+func (s *Server) issueSession(w http.ResponseWriter, user *storage.User) {
+	token := s.Sessions.Create(user.ID, user.Username, user.IsAdmin)
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.CookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int(s.CookieMaxAge.Seconds()),
+	})
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(auth.CookieName); err == nil {
+		s.Sessions.Delete(cookie.Value)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.CookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+
+	user, err := s.Users.ByID(sess.UserID)
+	if err != nil {
+		httpx.Error(w, 500, "Benutzerdaten nicht gefunden")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{
+		"username":     user.Username,
+		"displayName":  user.DisplayName,
+		"state":        user.State,
+		"defaultQuota": user.DefaultQuota,
+		"isAdmin":      user.IsAdmin,
+	})
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/holidays"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+// handleGetHolidays returns the public holidays for the caller's state in
+// the given year as a date->name map.
+func (s *Server) handleGetHolidays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 2000 || year > 2100 {
+		httpx.Error(w, 400, "Ungültiges Jahr")
+		return
+	}
+
+	sess := auth.SessionFromContext(r.Context())
+	user, err := s.Users.ByID(sess.UserID)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, holidays.ForState(year, user.State))
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+	"github.com/speze88/vacationplanner-go/pkg/ical"
+)
+
+// handleAbsencesICS returns the current user's absences as an iCalendar feed.
+func (s *Server) handleAbsencesICS(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+	s.writeAbsencesICS(w, sess.UserID)
+}
+
+// handleAdminUserAbsencesICS returns another user's absences as an
+// iCalendar feed, for admins.
+func (s *Server) handleAdminUserAbsencesICS(w http.ResponseWriter, r *http.Request) {
+	// /api/admin/users/{id}/absences.ics
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 5 {
+		httpx.Error(w, 400, "User-ID fehlt")
+		return
+	}
+	id, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		httpx.Error(w, 400, "Ungültige User-ID")
+		return
+	}
+
+	s.writeAbsencesICS(w, id)
+}
+
+// handleICalFeed serves GET /ical/{token}.ics without a session cookie, so
+// external calendar clients can subscribe directly.
+func (s *Server) handleICalFeed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/ical/"), ".ics")
+	if token == "" {
+		httpx.Error(w, 404, "Nicht gefunden")
+		return
+	}
+
+	user, err := s.Users.ByCalendarToken(token)
+	if err != nil {
+		httpx.Error(w, 404, "Nicht gefunden")
+		return
+	}
+
+	s.writeAbsencesICS(w, user.ID)
+}
+
+// handleRotateCalendarToken issues a new calendar_token for the current
+// user, invalidating any previously subscribed feed URL.
+func (s *Server) handleRotateCalendarToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+
+	sess := auth.SessionFromContext(r.Context())
+	token := auth.GenerateCalendarToken()
+	if err := s.Users.SetCalendarToken(sess.UserID, token); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{"token": token})
+}
+
+func (s *Server) writeAbsencesICS(w http.ResponseWriter, userID int64) {
+	entries, err := s.Absences.ListByUser(userID, "")
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	icalEntries := make(map[string]ical.Entry, len(entries))
+	for date, entry := range entries {
+		status := ""
+		if entry.Status != nil {
+			status = *entry.Status
+		}
+		if status == "rejected" {
+			continue
+		}
+		icalEntries[date] = ical.Entry{Type: entry.Type, Status: status}
+	}
+
+	feed, err := ical.BuildFeed(userID, icalEntries, time.Now())
+	if err != nil {
+		httpx.Error(w, 500, "Kalender konnte nicht erstellt werden")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(200)
+	w.Write([]byte(feed))
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+// passwordResetTTL is how long a self-service reset code stays valid.
+const passwordResetTTL = time.Hour
+
+// handleForgotPassword always returns 200, whether or not the username
+// exists, so the response can't be used to enumerate accounts. If the user
+// does exist, it mails them a single-use reset code.
+//
+// The username doubles as the mail address here, since this app has no
+// separate email field on users.
+func (s *Server) handleForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if user, err := s.Users.ByUsername(req.Username); err == nil {
+		token := auth.GenerateResetToken()
+		expiresAt := time.Now().Add(passwordResetTTL)
+		if err := s.PasswordResets.Create(auth.HashResetToken(token), user.ID, expiresAt); err != nil {
+			httpx.Error(w, 500, "Datenbankfehler")
+			return
+		}
+
+		body := "Code zum Zurücksetzen deines Passworts: " + token + "\nGültig für eine Stunde."
+		if err := s.Mailer.Send(user.Username, "Urlaubsplaner: Passwort zurücksetzen", body); err != nil {
+			log.Printf("password reset: cannot send mail: %v", err)
+		}
+	}
+
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+// handleResetPassword consumes a reset code and sets a new password for its
+// owner, invalidating all of their existing sessions.
+func (s *Server) handleResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+
+	var req struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil || req.Token == "" || req.NewPassword == "" {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	reset, err := s.PasswordResets.ByTokenHash(auth.HashResetToken(req.Token))
+	if err == sql.ErrNoRows {
+		httpx.Error(w, 400, "Ungültiger oder abgelaufener Code")
+		return
+	}
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if reset.Used || time.Now().After(reset.ExpiresAt) {
+		httpx.Error(w, 400, "Ungültiger oder abgelaufener Code")
+		return
+	}
+
+	hash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		httpx.Error(w, 500, "Passwort-Hashing fehlgeschlagen")
+		return
+	}
+	if err := s.Users.UpdatePassword(reset.UserID, hash); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if err := s.PasswordResets.MarkUsed(reset.TokenHash); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	s.Sessions.DeleteByUser(reset.UserID)
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
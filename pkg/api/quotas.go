@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+func (s *Server) handleGetQuotas(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+
+	quotas, err := s.Quotas.ListByUser(sess.UserID)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	result := map[string]float64{}
+	for year, quota := range quotas {
+		result[strconv.Itoa(year)] = quota
+	}
+
+	httpx.JSON(w, 200, result)
+}
+
+func (s *Server) handlePutQuota(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+
+	// Extract year from path: /api/quotas/2025
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 4 {
+		httpx.Error(w, 400, "Jahr fehlt")
+		return
+	}
+	yearStr := parts[len(parts)-1]
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year < 2000 || year > 2100 {
+		httpx.Error(w, 400, "Ungültiges Jahr")
+		return
+	}
+
+	var req struct {
+		Quota float64 `json:"quota"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	if err := s.Quotas.Put(sess.UserID, year, req.Quota); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
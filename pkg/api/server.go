@@ -0,0 +1,139 @@
+// Package api holds the typed HTTP handlers and router. Handlers are
+// methods on *Server so they share repositories and the session store
+// without relying on package-level globals.
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/authz"
+	"github.com/speze88/vacationplanner-go/pkg/mail"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+// Server holds the dependencies every handler needs.
+type Server struct {
+	Users          *storage.UserRepo
+	Absences       *storage.AbsenceRepo
+	Quotas         *storage.QuotaRepo
+	Groups         *storage.GroupRepo
+	Recovery       *storage.RecoveryCodeRepo
+	PasswordResets *storage.PasswordResetRepo
+	Sessions       auth.SessionStore
+	Challenges     *auth.ChallengeStore
+	Policy         *authz.Policy
+	Mailer         mail.Mailer
+	StaticDir      string
+	CookieMaxAge   time.Duration
+}
+
+// NewRouter builds the HTTP mux for s.
+func NewRouter(s *Server) http.Handler {
+	mux := http.NewServeMux()
+
+	// Auth
+	mux.HandleFunc("/api/login", s.handleLogin)
+	mux.HandleFunc("/api/logout", s.handleLogout)
+	mux.HandleFunc("/api/me", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleMe))
+
+	// Absences
+	mux.HandleFunc("/api/absences", auth.RequireAuth(s.Sessions, s.CookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			s.handleGetAbsences(w, r)
+		case "PUT":
+			s.handlePutAbsences(w, r)
+		case "DELETE":
+			s.handleDeleteAbsences(w, r)
+		default:
+			methodNotAllowed(w)
+		}
+	}))
+
+	// Quotas
+	mux.HandleFunc("/api/quotas", auth.RequireAuth(s.Sessions, s.CookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			s.handleGetQuotas(w, r)
+		} else {
+			methodNotAllowed(w)
+		}
+	}))
+	mux.HandleFunc("/api/quotas/", auth.RequireAuth(s.Sessions, s.CookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			s.handlePutQuota(w, r)
+		} else {
+			methodNotAllowed(w)
+		}
+	}))
+
+	// Settings
+	mux.HandleFunc("/api/settings", auth.RequireAuth(s.Sessions, s.CookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			s.handlePutSettings(w, r)
+		} else {
+			methodNotAllowed(w)
+		}
+	}))
+
+	// Admin
+	mux.HandleFunc("/api/admin/users", auth.RequireAdmin(s.Sessions, s.CookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			s.handleAdminGetUsers(w, r)
+		case "POST":
+			s.handleAdminCreateUser(w, r)
+		default:
+			methodNotAllowed(w)
+		}
+	}))
+	mux.HandleFunc("/api/admin/users/", auth.RequireAdmin(s.Sessions, s.CookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/password") && r.Method == "PUT" {
+			s.handleAdminResetPassword(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/absences.ics") && r.Method == "GET" {
+			s.handleAdminUserAbsencesICS(w, r)
+			return
+		}
+		if r.Method == "DELETE" {
+			s.handleAdminDeleteUser(w, r)
+		} else {
+			methodNotAllowed(w)
+		}
+	}))
+
+	// Team (leads and admins)
+	mux.HandleFunc("/api/team/absences", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleTeamAbsences))
+	mux.HandleFunc("/api/team/absences/", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleTeamAbsenceAction))
+	mux.HandleFunc("/api/team/quotas", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleTeamQuotas))
+
+	// Admin groups
+	mux.HandleFunc("/api/admin/groups", auth.RequireAdmin(s.Sessions, s.CookieMaxAge, s.handleAdminGroups))
+	mux.HandleFunc("/api/admin/groups/", auth.RequireAdmin(s.Sessions, s.CookieMaxAge, s.handleAdminGroupMembers))
+
+	// Two-factor authentication
+	mux.HandleFunc("/api/login/2fa", s.handleLogin2FA)
+	mux.HandleFunc("/api/2fa/setup", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleTOTPSetup))
+	mux.HandleFunc("/api/2fa/verify", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleTOTPVerify))
+	mux.HandleFunc("/api/2fa/disable", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleTOTPDisable))
+
+	// Password reset
+	mux.HandleFunc("/api/password/forgot", s.handleForgotPassword)
+	mux.HandleFunc("/api/password/reset", s.handleResetPassword)
+
+	// iCalendar export
+	mux.HandleFunc("/api/absences.ics", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleAbsencesICS))
+	mux.HandleFunc("/api/settings/calendar-token/rotate", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleRotateCalendarToken))
+	mux.HandleFunc("/ical/", s.handleICalFeed)
+
+	// Public holidays
+	mux.HandleFunc("/api/holidays", auth.RequireAuth(s.Sessions, s.CookieMaxAge, s.handleGetHolidays))
+
+	// Static files — serve index.html for root, otherwise from StaticDir
+	mux.HandleFunc("/", s.handleStatic)
+
+	return mux
+}
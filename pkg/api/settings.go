@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+func (s *Server) handlePutSettings(w http.ResponseWriter, r *http.Request) {
+	sess := auth.SessionFromContext(r.Context())
+
+	var req struct {
+		State        *string  `json:"state"`
+		DefaultQuota *float64 `json:"defaultQuota"`
+		DisplayName  *string  `json:"displayName"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	if err := s.Users.UpdateSettings(sess.UserID, req.State, req.DefaultQuota, req.DisplayName); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
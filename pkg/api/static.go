@@ -0,0 +1,21 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+func methodNotAllowed(w http.ResponseWriter) {
+	httpx.Error(w, 405, "Method not allowed")
+}
+
+// handleStatic serves index.html for the root path and falls back to
+// serving files from StaticDir for everything else.
+func (s *Server) handleStatic(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" || r.URL.Path == "/index.html" {
+		http.ServeFile(w, r, s.StaticDir+"/index.html")
+		return
+	}
+	http.ServeFile(w, r, s.StaticDir+r.URL.Path)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+// teamMemberIDs returns the ids of every user sess may see team data for:
+// everyone else for an admin, or the members of the groups sess leads.
+func (s *Server) teamMemberIDs(sess *auth.Session) ([]int64, error) {
+	if sess.IsAdmin {
+		users, err := s.Users.List()
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, 0, len(users))
+		for _, u := range users {
+			if u.ID != sess.UserID {
+				ids = append(ids, u.ID)
+			}
+		}
+		return ids, nil
+	}
+	return s.Groups.TeamMemberIDs(sess.UserID)
+}
+
+func (s *Server) handleTeamAbsences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+	sess := auth.SessionFromContext(r.Context())
+
+	allowed, err := s.Policy.IsLeadOrAdmin(sess)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if !allowed {
+		httpx.Error(w, 403, "Keine Berechtigung")
+		return
+	}
+
+	memberIDs, err := s.teamMemberIDs(sess)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	year := r.URL.Query().Get("year")
+	result := map[string]map[string]storage.AbsenceEntry{}
+	for _, id := range memberIDs {
+		entries, err := s.Absences.ListByUser(id, year)
+		if err != nil {
+			httpx.Error(w, 500, "Datenbankfehler")
+			return
+		}
+		result[strconv.FormatInt(id, 10)] = entries
+	}
+
+	httpx.JSON(w, 200, result)
+}
+
+func (s *Server) handleTeamQuotas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+	sess := auth.SessionFromContext(r.Context())
+
+	allowed, err := s.Policy.IsLeadOrAdmin(sess)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if !allowed {
+		httpx.Error(w, 403, "Keine Berechtigung")
+		return
+	}
+
+	memberIDs, err := s.teamMemberIDs(sess)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	result := map[string]map[string]float64{}
+	for _, id := range memberIDs {
+		quotas, err := s.Quotas.ListByUser(id)
+		if err != nil {
+			httpx.Error(w, 500, "Datenbankfehler")
+			return
+		}
+		byYear := map[string]float64{}
+		for year, quota := range quotas {
+			byYear[strconv.Itoa(year)] = quota
+		}
+		result[strconv.FormatInt(id, 10)] = byYear
+	}
+
+	httpx.JSON(w, 200, result)
+}
+
+// handleTeamAbsenceAction handles
+// POST /api/team/absences/{user_id}/{date}/approve and .../reject.
+func (s *Server) handleTeamAbsenceAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+
+	var status string
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/approve"):
+		status = "approved"
+	case strings.HasSuffix(r.URL.Path, "/reject"):
+		status = "rejected"
+	default:
+		httpx.Error(w, 404, "Nicht gefunden")
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 3 {
+		httpx.Error(w, 400, "Ungültiger Pfad")
+		return
+	}
+	userID, err := strconv.ParseInt(parts[len(parts)-3], 10, 64)
+	if err != nil {
+		httpx.Error(w, 400, "Ungültige User-ID")
+		return
+	}
+	date := parts[len(parts)-2]
+
+	sess := auth.SessionFromContext(r.Context())
+	if sess.UserID == userID {
+		httpx.Error(w, 403, "Keine Berechtigung")
+		return
+	}
+
+	isLeadOrAdmin, err := s.Policy.IsLeadOrAdmin(sess)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	canAct, err := s.Policy.CanActOn(sess, userID)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if !isLeadOrAdmin || !canAct {
+		httpx.Error(w, 403, "Keine Berechtigung")
+		return
+	}
+
+	if err := s.Absences.UpdateStatus(userID, date, status); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
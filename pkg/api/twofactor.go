@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+	"github.com/speze88/vacationplanner-go/pkg/totp"
+)
+
+const totpIssuer = "Urlaubsplaner"
+
+// recoveryCodeCount is how many single-use recovery codes are (re)generated
+// each time a user sets up 2FA.
+const recoveryCodeCount = 10
+
+// handleTOTPSetup generates a new, unconfirmed TOTP secret and a fresh set
+// of recovery codes for the current user. 2FA only takes effect once the
+// secret is confirmed via handleTOTPVerify.
+func (s *Server) handleTOTPSetup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+	sess := auth.SessionFromContext(r.Context())
+
+	secret := totp.GenerateSecret()
+	if err := s.Users.SetTOTPSecret(sess.UserID, secret); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		httpx.Error(w, 500, "Serverfehler")
+		return
+	}
+	if err := s.Recovery.ReplaceAll(sess.UserID, hashes); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	httpx.JSON(w, 200, map[string]any{
+		"secret":        secret,
+		"otpauth":       totp.URI(totpIssuer, sess.Username, secret),
+		"recoveryCodes": codes,
+	})
+}
+
+// handleTOTPVerify confirms a pending setup code and, on success, enables
+// 2FA for the current user.
+func (s *Server) handleTOTPVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+	sess := auth.SessionFromContext(r.Context())
+
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	user, err := s.Users.ByID(sess.UserID)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if !user.TOTPSecret.Valid {
+		httpx.Error(w, 400, "2FA ist nicht eingerichtet")
+		return
+	}
+	if !totp.Validate(user.TOTPSecret.String, req.Code, 1) {
+		httpx.Error(w, 401, "Ungültiger Code")
+		return
+	}
+
+	if err := s.Users.EnableTOTP(sess.UserID); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+// handleTOTPDisable turns 2FA back off for the current user and discards
+// their recovery codes.
+func (s *Server) handleTOTPDisable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+	sess := auth.SessionFromContext(r.Context())
+
+	if err := s.Users.DisableTOTP(sess.UserID); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	if err := s.Recovery.ReplaceAll(sess.UserID, nil); err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+// handleLogin2FA completes a login that handleLogin deferred because the
+// user has 2FA enabled. It accepts either a current TOTP code or one of the
+// user's recovery codes.
+func (s *Server) handleLogin2FA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		methodNotAllowed(w)
+		return
+	}
+
+	var req struct {
+		Challenge string `json:"challenge"`
+		Code      string `json:"code"`
+	}
+	if err := httpx.ReadJSON(r, &req); err != nil {
+		httpx.Error(w, 400, "Ungültige Anfrage")
+		return
+	}
+
+	userID, ok := s.Challenges.Consume(req.Challenge)
+	if !ok {
+		httpx.Error(w, 401, "Ungültige oder abgelaufene Anfrage")
+		return
+	}
+
+	user, err := s.Users.ByID(userID)
+	if err != nil {
+		httpx.Error(w, 500, "Datenbankfehler")
+		return
+	}
+
+	if !totp.Validate(user.TOTPSecret.String, req.Code, 1) && !s.consumeRecoveryCode(user.ID, req.Code) {
+		httpx.Error(w, 401, "Ungültiger Code")
+		return
+	}
+
+	s.issueSession(w, user)
+	httpx.JSON(w, 200, map[string]any{"ok": true})
+}
+
+// consumeRecoveryCode reports whether code matches one of userID's unused
+// recovery codes, marking it used if so.
+func (s *Server) consumeRecoveryCode(userID int64, code string) bool {
+	codes, err := s.Recovery.ListUnused(userID)
+	if err != nil {
+		return false
+	}
+	for _, c := range codes {
+		if auth.CheckPassword(c.Hash, code) == nil {
+			s.Recovery.MarkUsed(c.ID)
+			return true
+		}
+	}
+	return false
+}
+
+// generateRecoveryCodes returns recoveryCodeCount fresh recovery codes along
+// with their bcrypt hashes for storage.
+func generateRecoveryCodes() (codes []string, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		code := auth.GenerateRecoveryCode()
+		hash, err := auth.HashPassword(code)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hash)
+	}
+	return codes, hashes, nil
+}
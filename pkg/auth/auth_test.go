@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+func TestHashAndCheckPassword(t *testing.T) {
+	cases := []struct {
+		name     string
+		password string
+		attempt  string
+		wantErr  bool
+	}{
+		{"correct password", "s3cret!", "s3cret!", false},
+		{"wrong password", "s3cret!", "wrong", true},
+		{"empty password", "", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := HashPassword(tc.password)
+			if err != nil {
+				t.Fatalf("HashPassword: %v", err)
+			}
+			err = CheckPassword(hash, tc.attempt)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("CheckPassword error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	token := store.Create(42, "alice", true)
+
+	sess, ok := store.Get(token)
+	if !ok {
+		t.Fatalf("Get(%q) not found", token)
+	}
+	if sess.UserID != 42 || sess.Username != "alice" || !sess.IsAdmin {
+		t.Errorf("Get(%q) = %+v, want UserID 42 Username alice IsAdmin true", token, sess)
+	}
+
+	if _, ok := store.Get("does-not-exist"); ok {
+		t.Error("Get(unknown token) = ok, want not found")
+	}
+
+	store.Delete(token)
+	if _, ok := store.Get(token); ok {
+		t.Error("Get after Delete = ok, want not found")
+	}
+}
+
+func TestSQLStoreCreateGetTouchDelete(t *testing.T) {
+	db, err := storage.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	users := storage.NewUserRepo(db)
+	userID, err := users.Create("frank", "hash", "Frank", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	store, err := NewSQLStore(storage.NewSessionRepo(db), time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLStore: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := store.Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown: %v", err)
+		}
+	})
+
+	token := store.Create(userID, "frank", false)
+
+	sess, ok := store.Get(token)
+	if !ok {
+		t.Fatalf("Get(%q) not found", token)
+	}
+	if sess.UserID != userID || sess.Username != "frank" {
+		t.Errorf("Get(%q) = %+v, want UserID %d Username frank", token, sess, userID)
+	}
+
+	before := sess.ExpiresAt
+	store.Touch(token)
+	sess, ok = store.Get(token)
+	if !ok {
+		t.Fatalf("Get after Touch not found")
+	}
+	if !sess.ExpiresAt.After(before) {
+		t.Errorf("ExpiresAt after Touch = %v, want after %v", sess.ExpiresAt, before)
+	}
+
+	store.Delete(token)
+	if _, ok := store.Get(token); ok {
+		t.Error("Get after Delete = ok, want not found")
+	}
+}
+
+func TestSQLStoreLoadsUnexpiredOnStart(t *testing.T) {
+	db, err := storage.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	users := storage.NewUserRepo(db)
+	userID, err := users.Create("grace", "hash", "Grace", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	repo := storage.NewSessionRepo(db)
+	now := time.Now()
+	if err := repo.Create("tok-old", userID, false, now, now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("repo.Create: %v", err)
+	}
+
+	store, err := NewSQLStore(repo, time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLStore: %v", err)
+	}
+	t.Cleanup(func() { store.Shutdown(context.Background()) })
+
+	sess, ok := store.Get("tok-old")
+	if !ok {
+		t.Fatal("Get(tok-old) not found after restart, want loaded from storage")
+	}
+	if sess.Username != "grace" {
+		t.Errorf("Get(tok-old).Username = %q, want grace", sess.Username)
+	}
+}
+
+func TestSQLStoreDeleteByUser(t *testing.T) {
+	db, err := storage.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	users := storage.NewUserRepo(db)
+	userID, err := users.Create("henry", "hash", "Henry", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	store, err := NewSQLStore(storage.NewSessionRepo(db), time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewSQLStore: %v", err)
+	}
+	t.Cleanup(func() { store.Shutdown(context.Background()) })
+
+	token := store.Create(userID, "henry", false)
+	store.DeleteByUser(userID)
+
+	if _, ok := store.Get(token); ok {
+		t.Error("Get after DeleteByUser = ok, want not found")
+	}
+
+	// Also gone from storage, not just the in-memory cache.
+	rows, err := storage.NewSessionRepo(db).LoadUnexpired(time.Now())
+	if err != nil {
+		t.Fatalf("LoadUnexpired: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("LoadUnexpired after DeleteByUser = %+v, want empty", rows)
+	}
+}
+
+func TestChallengeStoreCreateConsume(t *testing.T) {
+	store := NewChallengeStore(time.Hour)
+
+	token := store.Create(7)
+
+	userID, ok := store.Consume(token)
+	if !ok || userID != 7 {
+		t.Errorf("Consume(%q) = %d, %v, want 7, true", token, userID, ok)
+	}
+
+	if _, ok := store.Consume(token); ok {
+		t.Error("Consume is not single-use: second call still succeeded")
+	}
+}
+
+func TestChallengeStoreExpiry(t *testing.T) {
+	store := NewChallengeStore(-time.Second)
+
+	token := store.Create(9)
+
+	if _, ok := store.Consume(token); ok {
+		t.Error("Consume accepted an already-expired challenge")
+	}
+}
+
+func TestHashResetTokenIsDeterministicAndDistinct(t *testing.T) {
+	tokenA := GenerateResetToken()
+	tokenB := GenerateResetToken()
+	if tokenA == tokenB {
+		t.Fatal("GenerateResetToken returned the same token twice in a row")
+	}
+
+	if HashResetToken(tokenA) != HashResetToken(tokenA) {
+		t.Error("HashResetToken is not deterministic")
+	}
+	if HashResetToken(tokenA) == HashResetToken(tokenB) {
+		t.Error("HashResetToken collided for distinct tokens")
+	}
+	if HashResetToken(tokenA) == tokenA {
+		t.Error("HashResetToken returned the token unchanged")
+	}
+}
+
+func TestMemoryStoreDeleteByUser(t *testing.T) {
+	store := NewMemoryStore()
+
+	tokenA := store.Create(1, "alice", false)
+	tokenB := store.Create(1, "alice", false)
+	tokenC := store.Create(2, "bob", false)
+
+	store.DeleteByUser(1)
+
+	if _, ok := store.Get(tokenA); ok {
+		t.Error("Get(tokenA) after DeleteByUser(1) = ok, want not found")
+	}
+	if _, ok := store.Get(tokenB); ok {
+		t.Error("Get(tokenB) after DeleteByUser(1) = ok, want not found")
+	}
+	if _, ok := store.Get(tokenC); !ok {
+		t.Error("Get(tokenC) after DeleteByUser(1) = not found, want ok (different user)")
+	}
+}
+
+func TestGenerateRecoveryCodeFormat(t *testing.T) {
+	code := GenerateRecoveryCode()
+	parts := strings.Split(code, "-")
+	if len(parts) != 3 {
+		t.Fatalf("GenerateRecoveryCode() = %q, want 3 dash-separated groups", code)
+	}
+	for _, p := range parts {
+		if len(p) != 4 {
+			t.Errorf("GenerateRecoveryCode() group %q, want length 4", p)
+		}
+	}
+
+	if GenerateRecoveryCode() == code {
+		t.Error("GenerateRecoveryCode() returned the same code twice in a row")
+	}
+}
+
+func TestGenerateCalendarTokenIsUniqueAndHexEncoded(t *testing.T) {
+	tokenA := GenerateCalendarToken()
+	tokenB := GenerateCalendarToken()
+
+	if tokenA == tokenB {
+		t.Fatal("GenerateCalendarToken returned the same token twice in a row")
+	}
+	if len(tokenA) != 48 {
+		t.Errorf("GenerateCalendarToken() = %q, want 48 hex characters", tokenA)
+	}
+}
@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// GenerateCalendarToken returns a random opaque token for subscribing to a
+// user's iCalendar feed without a session cookie. Unlike session or reset
+// tokens, it's stored and matched directly, since it only grants read access
+// to a calendar feed and is meant to be rotated, not expired.
+func GenerateCalendarToken() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
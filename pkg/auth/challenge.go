@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// ChallengeStore holds short-lived pending 2FA challenges created after a
+// password check succeeds but before a session is issued. Tokens are
+// single-use: Consume deletes them whether or not they were still valid.
+type ChallengeStore struct {
+	mu         sync.Mutex
+	challenges map[string]pendingChallenge
+	ttl        time.Duration
+}
+
+type pendingChallenge struct {
+	userID    int64
+	expiresAt time.Time
+}
+
+// NewChallengeStore returns an empty ChallengeStore whose tokens expire
+// after ttl.
+func NewChallengeStore(ttl time.Duration) *ChallengeStore {
+	return &ChallengeStore{challenges: map[string]pendingChallenge{}, ttl: ttl}
+}
+
+// Create records a pending challenge for userID and returns its token.
+func (s *ChallengeStore) Create(userID int64) string {
+	token := generateToken()
+	s.mu.Lock()
+	s.challenges[token] = pendingChallenge{userID: userID, expiresAt: time.Now().Add(s.ttl)}
+	s.mu.Unlock()
+	return token
+}
+
+// Consume looks up and deletes the challenge for token, reporting the user
+// it was issued for if it existed and had not yet expired.
+func (s *ChallengeStore) Consume(token string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[token]
+	delete(s.challenges, token)
+	if !ok || time.Now().After(c.expiresAt) {
+		return 0, false
+	}
+	return c.userID, true
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey int
+
+const sessionContextKey contextKey = 0
+
+func withSession(r *http.Request, s *Session) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), sessionContextKey, s))
+}
+
+// SessionFromContext returns the Session set by RequireAuth, or nil if
+// called outside of it.
+func SessionFromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionContextKey).(*Session)
+	return s
+}
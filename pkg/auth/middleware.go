@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/httpx"
+)
+
+// RequireAuth rejects requests without a valid session cookie, otherwise
+// makes the Session available to next via SessionFromContext. On success it
+// records activity on the session and refreshes the cookie's MaxAge.
+func RequireAuth(store SessionStore, cookieMaxAge time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(CookieName)
+		if err != nil {
+			httpx.Error(w, 401, "Nicht angemeldet")
+			return
+		}
+		s, ok := store.Get(cookie.Value)
+		if !ok {
+			httpx.Error(w, 401, "Nicht angemeldet")
+			return
+		}
+
+		store.Touch(cookie.Value)
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieName,
+			Value:    cookie.Value,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+			MaxAge:   int(cookieMaxAge.Seconds()),
+		})
+
+		next(w, withSession(r, s))
+	}
+}
+
+// RequireAdmin additionally rejects requests from non-admin sessions.
+func RequireAdmin(store SessionStore, cookieMaxAge time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return RequireAuth(store, cookieMaxAge, func(w http.ResponseWriter, r *http.Request) {
+		if !SessionFromContext(r.Context()).IsAdmin {
+			httpx.Error(w, 403, "Keine Berechtigung")
+			return
+		}
+		next(w, r)
+	})
+}
@@ -0,0 +1,16 @@
+// Package auth handles password hashing, session tokens, and the
+// RequireAuth/RequireAdmin HTTP middleware.
+package auth
+
+import "golang.org/x/crypto/bcrypt"
+
+// HashPassword bcrypt-hashes a plaintext password for storage.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// CheckPassword compares a bcrypt hash against a plaintext password.
+func CheckPassword(hash, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
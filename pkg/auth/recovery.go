@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// GenerateRecoveryCode returns a random single-use 2FA recovery code in
+// "xxxx-xxxx-xxxx" form. Callers must hash it with HashPassword before
+// storing it.
+func GenerateRecoveryCode() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x", b[0:2], b[2:4], b[4:6])
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GenerateResetToken returns a new random password-reset token. The raw
+// token is sent to the user; only its hash (see HashResetToken) is ever
+// stored, so a stolen database dump can't be replayed into a reset.
+func GenerateResetToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// HashResetToken returns the SHA-256 hash of a reset token, as stored in
+// the password_resets table.
+func HashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
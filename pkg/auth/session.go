@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// CookieName is the name of the session cookie.
+const CookieName = "session"
+
+// Session describes a logged-in user.
+type Session struct {
+	UserID    int64
+	Username  string
+	IsAdmin   bool
+	CreatedAt time.Time
+	LastSeen  time.Time
+	ExpiresAt time.Time
+}
+
+// SessionStore creates, looks up, and deletes sessions by their token.
+// Touch records activity on a session so idle-based implementations can
+// extend its expiry. DeleteByUser invalidates every session for a user, e.g.
+// after a password reset. Shutdown releases any resources held by the store
+// (flushing pending writes for persistent implementations); implementations
+// that need nothing to happen may treat it as a no-op.
+type SessionStore interface {
+	Create(userID int64, username string, isAdmin bool) string
+	Get(token string) (*Session, bool)
+	Touch(token string)
+	Delete(token string)
+	DeleteByUser(userID int64)
+	Shutdown(ctx context.Context) error
+}
+
+// MemoryStore is a SessionStore backed by an in-memory map. Sessions are
+// lost on restart and never expire.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string]*Session
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: map[string]*Session{}}
+}
+
+func (m *MemoryStore) Create(userID int64, username string, isAdmin bool) string {
+	token := generateToken()
+	now := time.Now()
+	m.mu.Lock()
+	m.sessions[token] = &Session{
+		UserID:    userID,
+		Username:  username,
+		IsAdmin:   isAdmin,
+		CreatedAt: now,
+		LastSeen:  now,
+	}
+	m.mu.Unlock()
+	return token
+}
+
+func (m *MemoryStore) Get(token string) (*Session, bool) {
+	m.mu.RLock()
+	s, ok := m.sessions[token]
+	m.mu.RUnlock()
+	return s, ok
+}
+
+// Touch is a no-op: in-memory sessions never expire.
+func (m *MemoryStore) Touch(token string) {}
+
+func (m *MemoryStore) Delete(token string) {
+	m.mu.Lock()
+	delete(m.sessions, token)
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) DeleteByUser(userID int64) {
+	m.mu.Lock()
+	for token, sess := range m.sessions {
+		if sess.UserID == userID {
+			delete(m.sessions, token)
+		}
+	}
+	m.mu.Unlock()
+}
+
+// Shutdown is a no-op: there is nothing to flush or close.
+func (m *MemoryStore) Shutdown(ctx context.Context) error { return nil }
+
+func generateToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+// evictInterval is how often the background evictor sweeps expired
+// sessions and flushes last_seen updates to disk.
+const evictInterval = 5 * time.Minute
+
+// SQLStore is a SessionStore backed by SQLite. Sessions survive restarts,
+// and expire MaxIdle after their last activity or MaxLifetime after
+// creation, whichever comes first.
+type SQLStore struct {
+	repo        *storage.SessionRepo
+	maxIdle     time.Duration
+	maxLifetime time.Duration
+
+	mu       sync.RWMutex
+	sessions map[string]*Session
+
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSQLStore loads unexpired sessions from repo and starts a background
+// evictor.
+func NewSQLStore(repo *storage.SessionRepo, maxIdle, maxLifetime time.Duration) (*SQLStore, error) {
+	rows, err := repo.LoadUnexpired(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SQLStore{
+		repo:        repo,
+		maxIdle:     maxIdle,
+		maxLifetime: maxLifetime,
+		sessions:    make(map[string]*Session, len(rows)),
+		ticker:      time.NewTicker(evictInterval),
+		done:        make(chan struct{}),
+	}
+	for _, row := range rows {
+		s.sessions[row.Token] = &Session{
+			UserID:    row.UserID,
+			Username:  row.Username,
+			IsAdmin:   row.IsAdmin,
+			CreatedAt: row.CreatedAt,
+			LastSeen:  row.LastSeen,
+			ExpiresAt: row.ExpiresAt,
+		}
+	}
+
+	s.wg.Add(1)
+	go s.evictLoop()
+	return s, nil
+}
+
+func (s *SQLStore) expiryFor(createdAt, lastSeen time.Time) time.Time {
+	expiresAt := lastSeen.Add(s.maxIdle)
+	if lifetimeCap := createdAt.Add(s.maxLifetime); lifetimeCap.Before(expiresAt) {
+		expiresAt = lifetimeCap
+	}
+	return expiresAt
+}
+
+func (s *SQLStore) Create(userID int64, username string, isAdmin bool) string {
+	token := generateToken()
+	now := time.Now()
+	expiresAt := s.expiryFor(now, now)
+
+	sess := &Session{
+		UserID:    userID,
+		Username:  username,
+		IsAdmin:   isAdmin,
+		CreatedAt: now,
+		LastSeen:  now,
+		ExpiresAt: expiresAt,
+	}
+
+	s.mu.Lock()
+	s.sessions[token] = sess
+	s.mu.Unlock()
+
+	if err := s.repo.Create(token, userID, isAdmin, now, now, expiresAt); err != nil {
+		log.Printf("session store: cannot persist session: %v", err)
+	}
+	return token
+}
+
+func (s *SQLStore) Get(token string) (*Session, bool) {
+	s.mu.RLock()
+	sess, ok := s.sessions[token]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		s.Delete(token)
+		return nil, false
+	}
+	return sess, true
+}
+
+// Touch extends a session's idle expiry in response to activity. The
+// update is persisted lazily by the background evictor and at Shutdown.
+func (s *SQLStore) Touch(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[token]
+	if !ok {
+		return
+	}
+	sess.LastSeen = time.Now()
+	sess.ExpiresAt = s.expiryFor(sess.CreatedAt, sess.LastSeen)
+}
+
+func (s *SQLStore) Delete(token string) {
+	s.mu.Lock()
+	delete(s.sessions, token)
+	s.mu.Unlock()
+	if err := s.repo.Delete(token); err != nil {
+		log.Printf("session store: cannot delete session: %v", err)
+	}
+}
+
+// DeleteByUser invalidates every session belonging to userID, e.g. after a
+// password reset.
+func (s *SQLStore) DeleteByUser(userID int64) {
+	s.mu.Lock()
+	for token, sess := range s.sessions {
+		if sess.UserID == userID {
+			delete(s.sessions, token)
+		}
+	}
+	s.mu.Unlock()
+	if err := s.repo.DeleteByUser(userID); err != nil {
+		log.Printf("session store: cannot delete sessions for user: %v", err)
+	}
+}
+
+func (s *SQLStore) evictLoop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ticker.C:
+			s.evictExpired()
+			if err := s.flush(); err != nil {
+				log.Printf("session store: cannot flush sessions: %v", err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *SQLStore) evictExpired() {
+	now := time.Now()
+	s.mu.Lock()
+	for token, sess := range s.sessions {
+		if now.After(sess.ExpiresAt) {
+			delete(s.sessions, token)
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.repo.DeleteExpired(now); err != nil {
+		log.Printf("session store: cannot evict expired sessions: %v", err)
+	}
+}
+
+func (s *SQLStore) flush() error {
+	type touch struct {
+		token     string
+		lastSeen  time.Time
+		expiresAt time.Time
+	}
+
+	s.mu.RLock()
+	touches := make([]touch, 0, len(s.sessions))
+	for token, sess := range s.sessions {
+		touches = append(touches, touch{token, sess.LastSeen, sess.ExpiresAt})
+	}
+	s.mu.RUnlock()
+
+	for _, t := range touches {
+		if err := s.repo.Touch(t.token, t.lastSeen, t.expiresAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown stops the background evictor and flushes pending last_seen
+// updates to the database. It does not close the database handle; the
+// caller owns that.
+func (s *SQLStore) Shutdown(ctx context.Context) error {
+	s.ticker.Stop()
+	close(s.done)
+	s.wg.Wait()
+	return s.flush()
+}
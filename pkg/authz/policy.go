@@ -0,0 +1,48 @@
+// Package authz centralizes the "can user X act on user Y's data" checks
+// for team leads and admins, so handlers don't need to special-case roles
+// themselves.
+package authz
+
+import (
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+// Policy answers authorization questions using group membership.
+type Policy struct {
+	Groups *storage.GroupRepo
+}
+
+// NewPolicy returns a Policy backed by groups.
+func NewPolicy(groups *storage.GroupRepo) *Policy {
+	return &Policy{Groups: groups}
+}
+
+// IsLeadOrAdmin reports whether sess may see team-wide data: admins always
+// can, and leads can for the groups they lead.
+func (p *Policy) IsLeadOrAdmin(sess *auth.Session) (bool, error) {
+	if sess.IsAdmin {
+		return true, nil
+	}
+	return p.Groups.IsLead(sess.UserID)
+}
+
+// CanActOn reports whether sess may read or modify targetUserID's absence
+// or quota data: everyone may act on their own data, admins may act on
+// anyone's, and leads may act on their own team's members.
+func (p *Policy) CanActOn(sess *auth.Session, targetUserID int64) (bool, error) {
+	if sess.UserID == targetUserID || sess.IsAdmin {
+		return true, nil
+	}
+
+	members, err := p.Groups.TeamMemberIDs(sess.UserID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range members {
+		if id == targetUserID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/speze88/vacationplanner-go/pkg/auth"
+	"github.com/speze88/vacationplanner-go/pkg/storage"
+)
+
+func newTestPolicy(t *testing.T) (*Policy, *storage.UserRepo, *storage.GroupRepo) {
+	t.Helper()
+	db, err := storage.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	groups := storage.NewGroupRepo(db)
+	return NewPolicy(groups), storage.NewUserRepo(db), groups
+}
+
+func TestPolicyIsLeadOrAdmin(t *testing.T) {
+	policy, users, groups := newTestPolicy(t)
+
+	adminID, _ := users.Create("admin1", "hash", "Admin", "BY", 30, true)
+	leadID, _ := users.Create("lead1", "hash", "Lead", "BY", 30, false)
+	userID, _ := users.Create("user1", "hash", "User", "BY", 30, false)
+
+	groupID, err := groups.Create("Team")
+	if err != nil {
+		t.Fatalf("Create group: %v", err)
+	}
+	if err := groups.AddMember(groupID, leadID, "lead"); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		sess *auth.Session
+		want bool
+	}{
+		{"admin", &auth.Session{UserID: adminID, IsAdmin: true}, true},
+		{"lead", &auth.Session{UserID: leadID}, true},
+		{"plain user", &auth.Session{UserID: userID}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := policy.IsLeadOrAdmin(tc.sess)
+			if err != nil {
+				t.Fatalf("IsLeadOrAdmin: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("IsLeadOrAdmin(%s) = %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPolicyCanActOn(t *testing.T) {
+	policy, users, groups := newTestPolicy(t)
+
+	adminID, _ := users.Create("admin2", "hash", "Admin", "BY", 30, true)
+	leadID, _ := users.Create("lead2", "hash", "Lead", "BY", 30, false)
+	memberID, _ := users.Create("member2", "hash", "Member", "BY", 30, false)
+	outsiderID, _ := users.Create("outsider2", "hash", "Outsider", "BY", 30, false)
+
+	groupID, err := groups.Create("Team2")
+	if err != nil {
+		t.Fatalf("Create group: %v", err)
+	}
+	if err := groups.AddMember(groupID, leadID, "lead"); err != nil {
+		t.Fatalf("AddMember(lead): %v", err)
+	}
+	if err := groups.AddMember(groupID, memberID, "member"); err != nil {
+		t.Fatalf("AddMember(member): %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		sess   *auth.Session
+		target int64
+		want   bool
+	}{
+		{"self", &auth.Session{UserID: memberID}, memberID, true},
+		{"admin on anyone", &auth.Session{UserID: adminID, IsAdmin: true}, outsiderID, true},
+		{"lead on team member", &auth.Session{UserID: leadID}, memberID, true},
+		{"lead on outsider", &auth.Session{UserID: leadID}, outsiderID, false},
+		{"member on member", &auth.Session{UserID: memberID}, outsiderID, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := policy.CanActOn(tc.sess, tc.target)
+			if err != nil {
+				t.Fatalf("CanActOn: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("CanActOn(%s) = %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
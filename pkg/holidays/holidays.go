@@ -0,0 +1,117 @@
+// Package holidays computes German public holidays per Bundesland. It has
+// no dependency on storage or http so it can be tested in isolation.
+package holidays
+
+import "time"
+
+// reformationTag lists the states where Reformationstag (Oct 31) is a
+// statewide holiday: the five traditional ones plus the four northern
+// states that made it permanent in 2018.
+var reformationTag = map[string]bool{
+	"BB": true, "MV": true, "SN": true, "ST": true, "TH": true,
+	"HB": true, "HH": true, "NI": true, "SH": true,
+}
+
+var heiligeDreiKoenige = map[string]bool{"BW": true, "BY": true, "ST": true}
+var fronleichnam = map[string]bool{"BW": true, "BY": true, "HE": true, "NW": true, "RP": true, "SL": true}
+var mariaeHimmelfahrt = map[string]bool{"SL": true}
+var allerheiligen = map[string]bool{"BW": true, "BY": true, "NW": true, "RP": true, "SL": true}
+var internationalerFrauentag = map[string]bool{"BE": true, "MV": true}
+
+// Easter returns the date of Gregorian Easter Sunday for year, via the
+// Anonymous Gregorian algorithm (Meeus/Jones/Butcher).
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := ((h + l - 7*m + 114) % 31) + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// bussUndBettag returns the Wednesday before November 23 of year, the only
+// movable feast not derived from Easter (Saxony's Buß- und Bettag).
+func bussUndBettag(year int) time.Time {
+	nov23 := time.Date(year, time.November, 23, 0, 0, 0, 0, time.UTC)
+	daysSinceWednesday := (int(nov23.Weekday()) - int(time.Wednesday) + 7) % 7
+	if daysSinceWednesday == 0 {
+		daysSinceWednesday = 7
+	}
+	return nov23.AddDate(0, 0, -daysSinceWednesday)
+}
+
+// ForState returns the date->name map of public holidays in state for year.
+// Dates are formatted as "2006-01-02".
+func ForState(year int, state string) map[string]string {
+	easter := Easter(year)
+	result := map[string]string{}
+
+	set := func(t time.Time, name string) {
+		result[t.Format("2006-01-02")] = name
+	}
+
+	set(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), "Neujahr")
+	set(easter.AddDate(0, 0, -2), "Karfreitag")
+	set(easter.AddDate(0, 0, 1), "Ostermontag")
+	set(time.Date(year, time.May, 1, 0, 0, 0, 0, time.UTC), "Tag der Arbeit")
+	set(easter.AddDate(0, 0, 39), "Christi Himmelfahrt")
+	set(easter.AddDate(0, 0, 50), "Pfingstmontag")
+	set(time.Date(year, time.October, 3, 0, 0, 0, 0, time.UTC), "Tag der Deutschen Einheit")
+	set(time.Date(year, time.December, 25, 0, 0, 0, 0, time.UTC), "1. Weihnachtstag")
+	set(time.Date(year, time.December, 26, 0, 0, 0, 0, time.UTC), "2. Weihnachtstag")
+
+	if heiligeDreiKoenige[state] {
+		set(time.Date(year, time.January, 6, 0, 0, 0, 0, time.UTC), "Heilige Drei Könige")
+	}
+	if fronleichnam[state] {
+		set(easter.AddDate(0, 0, 60), "Fronleichnam")
+	}
+	if mariaeHimmelfahrt[state] {
+		set(time.Date(year, time.August, 15, 0, 0, 0, 0, time.UTC), "Mariä Himmelfahrt")
+	}
+	if allerheiligen[state] {
+		set(time.Date(year, time.November, 1, 0, 0, 0, 0, time.UTC), "Allerheiligen")
+	}
+	if reformationTag[state] {
+		set(time.Date(year, time.October, 31, 0, 0, 0, 0, time.UTC), "Reformationstag")
+	}
+	if internationalerFrauentag[state] {
+		set(time.Date(year, time.March, 8, 0, 0, 0, 0, time.UTC), "Internationaler Frauentag")
+	}
+	if state == "SN" {
+		set(bussUndBettag(year), "Buß- und Bettag")
+	}
+	if state == "TH" {
+		set(time.Date(year, time.September, 20, 0, 0, 0, 0, time.UTC), "Weltkindertag")
+	}
+	if state == "BB" {
+		set(easter, "Ostersonntag")
+		set(easter.AddDate(0, 0, 49), "Pfingstsonntag")
+	}
+
+	return result
+}
+
+// IsHoliday reports whether date is a public holiday in state.
+func IsHoliday(date time.Time, state string) bool {
+	_, ok := ForState(date.Year(), state)[date.Format("2006-01-02")]
+	return ok
+}
+
+// IsWorkingDay reports whether date is neither a weekend day nor a public
+// holiday in state.
+func IsWorkingDay(date time.Time, state string) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !IsHoliday(date, state)
+}
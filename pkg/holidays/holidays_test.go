@@ -0,0 +1,165 @@
+package holidays
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestEasterKnownDates(t *testing.T) {
+	cases := map[int]string{
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2026: "2026-04-05",
+	}
+	for year, want := range cases {
+		if got := Easter(year).Format("2006-01-02"); got != want {
+			t.Errorf("Easter(%d) = %s, want %s", year, got, want)
+		}
+	}
+}
+
+func TestForStateMovableFeastsAcrossYears(t *testing.T) {
+	cases := []struct {
+		year               int
+		karfreitag         string
+		ostermontag        string
+		christiHimmelfahrt string
+		pfingstmontag      string
+		fronleichnam       string
+	}{
+		{2024, "2024-03-29", "2024-04-01", "2024-05-09", "2024-05-20", "2024-05-30"},
+		{2025, "2025-04-18", "2025-04-21", "2025-05-29", "2025-06-09", "2025-06-19"},
+		{2026, "2026-04-03", "2026-04-06", "2026-05-14", "2026-05-25", "2026-06-04"},
+	}
+
+	for _, tc := range cases {
+		holidays := ForState(tc.year, "BY")
+		if holidays[tc.karfreitag] != "Karfreitag" {
+			t.Errorf("%d: holidays[%s] = %q, want Karfreitag", tc.year, tc.karfreitag, holidays[tc.karfreitag])
+		}
+		if holidays[tc.ostermontag] != "Ostermontag" {
+			t.Errorf("%d: holidays[%s] = %q, want Ostermontag", tc.year, tc.ostermontag, holidays[tc.ostermontag])
+		}
+		if holidays[tc.christiHimmelfahrt] != "Christi Himmelfahrt" {
+			t.Errorf("%d: holidays[%s] = %q, want Christi Himmelfahrt", tc.year, tc.christiHimmelfahrt, holidays[tc.christiHimmelfahrt])
+		}
+		if holidays[tc.pfingstmontag] != "Pfingstmontag" {
+			t.Errorf("%d: holidays[%s] = %q, want Pfingstmontag", tc.year, tc.pfingstmontag, holidays[tc.pfingstmontag])
+		}
+		if holidays[tc.fronleichnam] != "Fronleichnam" {
+			t.Errorf("%d: holidays[%s] = %q, want Fronleichnam", tc.year, tc.fronleichnam, holidays[tc.fronleichnam])
+		}
+	}
+}
+
+func TestBussUndBettagKnownDates(t *testing.T) {
+	cases := map[int]string{
+		2024: "2024-11-20",
+		2025: "2025-11-19",
+		2026: "2026-11-18",
+	}
+	for year, want := range cases {
+		holidays := ForState(year, "SN")
+		if holidays[want] != "Buß- und Bettag" {
+			t.Errorf("ForState(%d, SN)[%s] = %q, want Buß- und Bettag", year, want, holidays[want])
+		}
+	}
+}
+
+// allStates are the 16 German Bundesländer's ISO-ish codes, as used by the
+// storage layer's users.state column.
+var allStates = []string{
+	"BW", "BY", "BE", "BB", "HB", "HH", "HE", "MV",
+	"NI", "NW", "RP", "SL", "SN", "ST", "SH", "TH",
+}
+
+func TestForStateFixedHolidaysPresentEverywhere(t *testing.T) {
+	fixed := map[string]string{
+		"01-01": "Neujahr",
+		"05-01": "Tag der Arbeit",
+		"10-03": "Tag der Deutschen Einheit",
+		"12-25": "1. Weihnachtstag",
+		"12-26": "2. Weihnachtstag",
+	}
+	for _, year := range []int{2024, 2025, 2026} {
+		for _, state := range allStates {
+			holidays := ForState(year, state)
+			for suffix, name := range fixed {
+				date := strconv.Itoa(year) + "-" + suffix
+				if holidays[date] != name {
+					t.Errorf("ForState(%d, %s)[%s] = %q, want %q", year, state, date, holidays[date], name)
+				}
+			}
+		}
+	}
+}
+
+func TestForStateRegionalHolidays(t *testing.T) {
+	cases := []struct {
+		name        string
+		date        string
+		wantStates  []string
+		otherStates []string
+	}{
+		{"Heilige Drei Könige", "2025-01-06", []string{"BW", "BY", "ST"}, []string{"BE", "NW"}},
+		{"Fronleichnam", "2025-06-19", []string{"BW", "BY", "HE", "NW", "RP", "SL"}, []string{"BE", "HB", "SN"}},
+		{"Mariä Himmelfahrt", "2025-08-15", []string{"SL"}, []string{"BY", "BW", "BE"}},
+		{"Allerheiligen", "2025-11-01", []string{"BW", "BY", "NW", "RP", "SL"}, []string{"BE", "SN"}},
+		{"Reformationstag", "2025-10-31", []string{"BB", "HB", "HH", "MV", "NI", "SH", "SN", "ST", "TH"}, []string{"BW", "BY", "BE", "HE", "NW", "RP", "SL"}},
+		{"Weltkindertag", "2025-09-20", []string{"TH"}, []string{"BY", "SN"}},
+		{"Internationaler Frauentag", "2025-03-08", []string{"BE", "MV"}, []string{"BY", "NW"}},
+	}
+
+	for _, tc := range cases {
+		for _, state := range tc.wantStates {
+			holidays := ForState(2025, state)
+			if _, ok := holidays[tc.date]; !ok {
+				t.Errorf("%s: ForState(2025, %s) missing %s", tc.name, state, tc.date)
+			}
+		}
+		for _, state := range tc.otherStates {
+			holidays := ForState(2025, state)
+			if _, ok := holidays[tc.date]; ok {
+				t.Errorf("%s: ForState(2025, %s) unexpectedly has %s", tc.name, state, tc.date)
+			}
+		}
+	}
+}
+
+func TestForStateBrandenburgEasterAndPentecostSunday(t *testing.T) {
+	holidays := ForState(2025, "BB")
+	if holidays["2025-04-20"] != "Ostersonntag" {
+		t.Errorf("ForState(2025, BB)[2025-04-20] = %q, want Ostersonntag", holidays["2025-04-20"])
+	}
+	if holidays["2025-06-08"] != "Pfingstsonntag" {
+		t.Errorf("ForState(2025, BB)[2025-06-08] = %q, want Pfingstsonntag", holidays["2025-06-08"])
+	}
+
+	other := ForState(2025, "BY")
+	if _, ok := other["2025-04-20"]; ok {
+		t.Error("ForState(2025, BY) unexpectedly has Ostersonntag")
+	}
+}
+
+func TestIsWorkingDay(t *testing.T) {
+	cases := []struct {
+		name  string
+		date  time.Time
+		state string
+		want  bool
+	}{
+		{"regular weekday", time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC), "BY", true},
+		{"weekend", time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC), "BY", false},
+		{"public holiday", time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC), "BY", false},
+		{"regional holiday elsewhere", time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC), "BY", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsWorkingDay(tc.date, tc.state); got != tc.want {
+				t.Errorf("IsWorkingDay(%s, %s) = %v, want %v", tc.date.Format("2006-01-02"), tc.state, got, tc.want)
+			}
+		})
+	}
+}
+
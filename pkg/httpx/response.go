@@ -0,0 +1,26 @@
+// Package httpx holds small HTTP helpers shared across the auth and api
+// packages so neither has to depend on the other just to write a JSON error.
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JSON writes data as a JSON response with the given status code.
+func JSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// Error writes a JSON error response of the form {"error": msg}.
+func Error(w http.ResponseWriter, status int, msg string) {
+	JSON(w, status, map[string]string{"error": msg})
+}
+
+// ReadJSON decodes the request body into v and closes it.
+func ReadJSON(r *http.Request, v any) error {
+	defer r.Body.Close()
+	return json.NewDecoder(r.Body).Decode(v)
+}
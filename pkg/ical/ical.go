@@ -0,0 +1,103 @@
+// Package ical renders absence data as a minimal RFC 5545 calendar feed. It
+// has no dependency on storage or http so the format can be built and tested
+// in isolation.
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// summaries maps an absence type code to its calendar SUMMARY text.
+var summaries = map[string]string{
+	"UR":   "Urlaub",
+	"UR/2": "Urlaub (½)",
+	"SUR":  "Sonderurlaub",
+	"UUR":  "Unbezahlter Urlaub",
+}
+
+// icsStatus maps an absence status to its RFC 5545 VEVENT STATUS value.
+// Absences with no status (recorded before approval tracking existed) are
+// treated as confirmed, same as "approved".
+var icsStatus = map[string]string{
+	"approved": "CONFIRMED",
+	"pending":  "TENTATIVE",
+}
+
+// Entry is one day's absence type and approval status, as rendered into a
+// calendar feed.
+type Entry struct {
+	Type   string
+	Status string
+}
+
+// BuildFeed renders entries (date in "2006-01-02" form -> Entry) as a
+// VCALENDAR with one VEVENT per run of consecutive dates sharing the same
+// type and status. Each event gets an all-day DTSTART and a DTEND the day
+// after its last date, per RFC 5545's half-open convention, and a STATUS
+// reflecting the absence's approval state. now is stamped as
+// DTSTAMP/LAST-MODIFIED on every event, in UTC. Callers are expected to have
+// already excluded rejected absences.
+func BuildFeed(userID int64, entries map[string]Entry, now time.Time) (string, error) {
+	type day struct {
+		date   time.Time
+		typ    string
+		status string
+	}
+
+	days := make([]day, 0, len(entries))
+	for dateStr, entry := range entries {
+		t, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q: %w", dateStr, err)
+		}
+		days = append(days, day{date: t, typ: entry.Type, status: entry.Status})
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].date.Before(days[j].date) })
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//Urlaubsplaner//Absences//DE\r\n")
+	b.WriteString("CALSCALE:GREGORIAN\r\n")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	for i := 0; i < len(days); {
+		j := i + 1
+		for j < len(days) && days[j].typ == days[i].typ && days[j].status == days[i].status &&
+			days[j].date.Equal(days[j-1].date.AddDate(0, 0, 1)) {
+			j++
+		}
+
+		start := days[i].date
+		end := days[j-1].date.AddDate(0, 0, 1)
+
+		summary := summaries[days[i].typ]
+		if summary == "" {
+			summary = days[i].typ
+		}
+
+		status := icsStatus[days[i].status]
+		if status == "" {
+			status = "CONFIRMED"
+		}
+
+		b.WriteString("BEGIN:VEVENT\r\n")
+		fmt.Fprintf(&b, "UID:%d-%s-%s@urlaubsplaner.local\r\n", userID, start.Format("20060102"), days[i].typ)
+		fmt.Fprintf(&b, "DTSTAMP:%s\r\n", stamp)
+		fmt.Fprintf(&b, "LAST-MODIFIED:%s\r\n", stamp)
+		fmt.Fprintf(&b, "DTSTART;VALUE=DATE:%s\r\n", start.Format("20060102"))
+		fmt.Fprintf(&b, "DTEND;VALUE=DATE:%s\r\n", end.Format("20060102"))
+		fmt.Fprintf(&b, "SUMMARY:%s\r\n", summary)
+		fmt.Fprintf(&b, "STATUS:%s\r\n", status)
+		b.WriteString("END:VEVENT\r\n")
+
+		i = j
+	}
+
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String(), nil
+}
@@ -0,0 +1,117 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildFeedMergesConsecutiveSameTypeDates(t *testing.T) {
+	dates := map[string]Entry{
+		"2025-06-02": {Type: "UR"},
+		"2025-06-03": {Type: "UR"},
+		"2025-06-04": {Type: "UR"},
+		"2025-06-10": {Type: "SUR"},
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	feed, err := BuildFeed(7, dates, now)
+	if err != nil {
+		t.Fatalf("BuildFeed: %v", err)
+	}
+
+	if strings.Count(feed, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("feed = %q, want 2 VEVENTs", feed)
+	}
+	if !strings.Contains(feed, "DTSTART;VALUE=DATE:20250602") {
+		t.Error("missing merged run's DTSTART")
+	}
+	if !strings.Contains(feed, "DTEND;VALUE=DATE:20250605") {
+		t.Error("DTEND should be the day after the run's last date (half-open), want 20250605")
+	}
+	if !strings.Contains(feed, "SUMMARY:Urlaub") {
+		t.Error("missing SUMMARY:Urlaub for type UR")
+	}
+	if !strings.Contains(feed, "SUMMARY:Sonderurlaub") {
+		t.Error("missing SUMMARY:Sonderurlaub for type SUR")
+	}
+	if !strings.Contains(feed, "DTSTAMP:20250601T120000Z") {
+		t.Error("missing UTC DTSTAMP")
+	}
+}
+
+func TestBuildFeedDoesNotMergeAcrossAGap(t *testing.T) {
+	dates := map[string]Entry{
+		"2025-06-02": {Type: "UR"},
+		"2025-06-04": {Type: "UR"},
+	}
+
+	feed, err := BuildFeed(1, dates, time.Now())
+	if err != nil {
+		t.Fatalf("BuildFeed: %v", err)
+	}
+	if strings.Count(feed, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("feed = %q, want 2 separate VEVENTs for non-consecutive dates", feed)
+	}
+}
+
+func TestBuildFeedUIDIsStablePerUserStartAndType(t *testing.T) {
+	dates := map[string]Entry{"2025-06-02": {Type: "UR"}}
+
+	feedA, err := BuildFeed(42, dates, time.Now())
+	if err != nil {
+		t.Fatalf("BuildFeed: %v", err)
+	}
+	feedB, err := BuildFeed(42, dates, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("BuildFeed: %v", err)
+	}
+
+	const wantUID = "UID:42-20250602-UR@urlaubsplaner.local"
+	if !strings.Contains(feedA, wantUID) || !strings.Contains(feedB, wantUID) {
+		t.Errorf("UID is not stable across rebuilds: %q / %q, want both to contain %q", feedA, feedB, wantUID)
+	}
+}
+
+func TestBuildFeedRejectsInvalidDate(t *testing.T) {
+	if _, err := BuildFeed(1, map[string]Entry{"not-a-date": {Type: "UR"}}, time.Now()); err == nil {
+		t.Error("BuildFeed with an invalid date = nil error, want error")
+	}
+}
+
+func TestBuildFeedStatusReflectsApprovalState(t *testing.T) {
+	dates := map[string]Entry{
+		"2025-06-02": {Type: "UR", Status: "approved"},
+		"2025-06-10": {Type: "UR", Status: "pending"},
+		"2025-06-20": {Type: "UR"},
+	}
+
+	feed, err := BuildFeed(1, dates, time.Now())
+	if err != nil {
+		t.Fatalf("BuildFeed: %v", err)
+	}
+	if !strings.Contains(feed, "DTSTART;VALUE=DATE:20250602") {
+		t.Fatal("missing approved entry")
+	}
+	if strings.Count(feed, "STATUS:CONFIRMED") != 2 {
+		t.Errorf("feed = %q, want STATUS:CONFIRMED for the approved entry and the one with no status", feed)
+	}
+	if !strings.Contains(feed, "STATUS:TENTATIVE") {
+		t.Error("missing STATUS:TENTATIVE for pending entry")
+	}
+}
+
+func TestBuildFeedDoesNotMergeAcrossStatusChange(t *testing.T) {
+	dates := map[string]Entry{
+		"2025-06-02": {Type: "UR", Status: "approved"},
+		"2025-06-03": {Type: "UR", Status: "pending"},
+	}
+
+	feed, err := BuildFeed(1, dates, time.Now())
+	if err != nil {
+		t.Fatalf("BuildFeed: %v", err)
+	}
+	if strings.Count(feed, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("feed = %q, want 2 separate VEVENTs for a status change mid-run", feed)
+	}
+}
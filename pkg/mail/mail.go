@@ -0,0 +1,57 @@
+// Package mail sends outbound email, e.g. password-reset codes, behind a
+// small Mailer interface so the SMTP implementation can be swapped for a
+// stdout one in development.
+package mail
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+)
+
+// Mailer sends a single plain-text email.
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// SMTPConfig holds the settings needed to send mail through an SMTP relay.
+type SMTPConfig struct {
+	Host string
+	Port string
+	User string
+	Pass string
+	From string
+}
+
+// SMTPMailer sends mail through an SMTP relay using PLAIN auth.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer returns an SMTPMailer configured from cfg.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send delivers the message through the configured SMTP relay.
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	addr := fmt.Sprintf("%s:%s", m.cfg.Host, m.cfg.Port)
+	auth := smtp.PlainAuth("", m.cfg.User, m.cfg.Pass, m.cfg.Host)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", m.cfg.From, to, subject, body)
+	return smtp.SendMail(addr, auth, m.cfg.From, []string{to}, []byte(msg))
+}
+
+// StdoutMailer logs mail to stdout instead of sending it. It's the default
+// when SMTP is not configured, e.g. in local development.
+type StdoutMailer struct{}
+
+// NewStdoutMailer returns a StdoutMailer.
+func NewStdoutMailer() *StdoutMailer {
+	return &StdoutMailer{}
+}
+
+// Send logs the message instead of delivering it.
+func (m *StdoutMailer) Send(to, subject, body string) error {
+	log.Printf("mail (dev): to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
@@ -0,0 +1,10 @@
+package mail
+
+import "testing"
+
+func TestStdoutMailerSend(t *testing.T) {
+	m := NewStdoutMailer()
+	if err := m.Send("alice@example.com", "Test", "body"); err != nil {
+		t.Errorf("Send: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import "database/sql"
+
+// AbsenceRepo provides typed access to the absences table.
+type AbsenceRepo struct {
+	db *DB
+}
+
+// NewAbsenceRepo returns an AbsenceRepo backed by db.
+func NewAbsenceRepo(db *DB) *AbsenceRepo {
+	return &AbsenceRepo{db: db}
+}
+
+// AbsenceEntry is one day's absence type and, for tracked submissions, its
+// approval status ("pending", "approved", or "rejected"). Status is nil for
+// absences recorded before approval tracking existed.
+type AbsenceEntry struct {
+	Type   string  `json:"type"`
+	Status *string `json:"status,omitempty"`
+}
+
+// ListByUser returns the date->entry map for userID, optionally restricted
+// to a year (e.g. "2025"). Pass "" for all years.
+func (r *AbsenceRepo) ListByUser(userID int64, year string) (map[string]AbsenceEntry, error) {
+	var rows *sql.Rows
+	var err error
+
+	if year != "" {
+		rows, err = r.db.Query("SELECT date, type, status FROM absences WHERE user_id = ? AND date LIKE ?", userID, year+"-%")
+	} else {
+		rows, err = r.db.Query("SELECT date, type, status FROM absences WHERE user_id = ?", userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[string]AbsenceEntry{}
+	for rows.Next() {
+		var date, typ string
+		var status sql.NullString
+		if err := rows.Scan(&date, &typ, &status); err != nil {
+			return nil, err
+		}
+		entry := AbsenceEntry{Type: typ}
+		if status.Valid {
+			entry.Status = &status.String
+		}
+		result[date] = entry
+	}
+	return result, rows.Err()
+}
+
+// PutMany upserts the given date->type entries for userID with the given
+// approval status, in one transaction, and returns how many were written.
+func (r *AbsenceRepo) PutMany(userID int64, dates map[string]string, status string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.Prepare("INSERT OR REPLACE INTO absences (user_id, date, type, status) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for date, typ := range dates {
+		if _, err := stmt.Exec(userID, date, typ, status); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(dates), nil
+}
+
+// UpdateStatus sets the approval status of a single absence.
+func (r *AbsenceRepo) UpdateStatus(userID int64, date, status string) error {
+	_, err := r.db.Exec("UPDATE absences SET status = ? WHERE user_id = ? AND date = ?", status, userID, date)
+	return err
+}
+
+// DeleteMany removes the given dates for userID in one transaction and
+// returns how many delete statements were executed.
+func (r *AbsenceRepo) DeleteMany(userID int64, dates []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.Prepare("DELETE FROM absences WHERE user_id = ? AND date = ?")
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for _, date := range dates {
+		if _, err := stmt.Exec(userID, date); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(dates), nil
+}
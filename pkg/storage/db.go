@@ -0,0 +1,127 @@
+// Package storage owns the SQLite connection, schema migrations, and typed
+// repositories for users, absences, and quotas. Nothing outside this package
+// should run SQL directly.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// DB wraps a *sql.DB so repositories share one connection and one place to
+// add cross-cutting behaviour (pragmas, migrations) later.
+type DB struct {
+	*sql.DB
+}
+
+// Open creates the data directory if needed, opens the SQLite database at
+// path, and runs all migrations.
+func Open(path string) (*DB, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("cannot create data dir %s: %w", dir, err)
+	}
+
+	sqlDB, err := sql.Open("sqlite3", path+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database: %w", err)
+	}
+
+	db := &DB{sqlDB}
+	if err := db.migrate(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *DB) migrate() error {
+	migrations := []string{
+		`CREATE TABLE IF NOT EXISTS users (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT UNIQUE NOT NULL,
+			password_hash TEXT NOT NULL,
+			display_name TEXT NOT NULL,
+			state TEXT DEFAULT 'BY',
+			default_quota REAL DEFAULT 30,
+			is_admin INTEGER DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS quotas (
+			user_id INTEGER NOT NULL,
+			year INTEGER NOT NULL,
+			quota REAL NOT NULL,
+			PRIMARY KEY (user_id, year),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS absences (
+			user_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			type TEXT NOT NULL,
+			PRIMARY KEY (user_id, date),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS sessions (
+			token TEXT PRIMARY KEY,
+			user_id INTEGER NOT NULL,
+			is_admin INTEGER NOT NULL,
+			created_at DATETIME NOT NULL,
+			last_seen DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS groups (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT UNIQUE NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS group_members (
+			user_id INTEGER NOT NULL,
+			group_id INTEGER NOT NULL,
+			role TEXT NOT NULL DEFAULT 'member',
+			PRIMARY KEY (user_id, group_id),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+		)`,
+		`ALTER TABLE absences ADD COLUMN status TEXT`,
+		`ALTER TABLE users ADD COLUMN totp_secret TEXT`,
+		`ALTER TABLE users ADD COLUMN totp_enabled INTEGER NOT NULL DEFAULT 0`,
+		`CREATE TABLE IF NOT EXISTS recovery_codes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			code_hash TEXT NOT NULL,
+			used INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS password_resets (
+			token_hash TEXT PRIMARY KEY,
+			user_id INTEGER NOT NULL,
+			expires_at DATETIME NOT NULL,
+			used INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`ALTER TABLE users ADD COLUMN calendar_token TEXT`,
+	}
+
+	for _, m := range migrations {
+		if _, err := db.Exec(m); err != nil && !isDuplicateColumnError(err) {
+			return fmt.Errorf("migration failed: %w\n%s", err, m)
+		}
+	}
+
+	// Enable foreign keys
+	db.Exec("PRAGMA foreign_keys = ON")
+	return nil
+}
+
+// isDuplicateColumnError reports whether err is SQLite's "duplicate column
+// name" error, which ALTER TABLE ADD COLUMN returns when it's run a second
+// time against a database that already has the column. SQLite has no ADD
+// COLUMN IF NOT EXISTS, so tolerating this error is what keeps migrate()
+// safe to run on every startup.
+func isDuplicateColumnError(err error) bool {
+	return strings.Contains(err.Error(), "duplicate column name")
+}
@@ -0,0 +1,120 @@
+package storage
+
+// Group is a team that absence approvals and team views can be scoped to.
+type Group struct {
+	ID   int64
+	Name string
+}
+
+// GroupMember is a user's membership and role ("member" or "lead") within
+// a group.
+type GroupMember struct {
+	UserID   int64
+	GroupID  int64
+	Role     string
+	Username string
+}
+
+// GroupRepo provides typed access to the groups and group_members tables.
+type GroupRepo struct {
+	db *DB
+}
+
+// NewGroupRepo returns a GroupRepo backed by db.
+func NewGroupRepo(db *DB) *GroupRepo {
+	return &GroupRepo{db: db}
+}
+
+// Create inserts a new group and returns its id.
+func (r *GroupRepo) Create(name string) (int64, error) {
+	res, err := r.db.Exec("INSERT INTO groups (name) VALUES (?)", name)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// List returns all groups ordered by id.
+func (r *GroupRepo) List() ([]Group, error) {
+	rows, err := r.db.Query("SELECT id, name FROM groups ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []Group
+	for rows.Next() {
+		var g Group
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// AddMember upserts userID's membership and role in groupID.
+func (r *GroupRepo) AddMember(groupID, userID int64, role string) error {
+	_, err := r.db.Exec("INSERT OR REPLACE INTO group_members (user_id, group_id, role) VALUES (?, ?, ?)", userID, groupID, role)
+	return err
+}
+
+// RemoveMember removes userID's membership in groupID.
+func (r *GroupRepo) RemoveMember(groupID, userID int64) error {
+	_, err := r.db.Exec("DELETE FROM group_members WHERE user_id = ? AND group_id = ?", userID, groupID)
+	return err
+}
+
+// MembersOf returns every member of groupID along with their username.
+func (r *GroupRepo) MembersOf(groupID int64) ([]GroupMember, error) {
+	rows, err := r.db.Query(
+		`SELECT gm.user_id, gm.group_id, gm.role, u.username
+		 FROM group_members gm JOIN users u ON u.id = gm.user_id
+		 WHERE gm.group_id = ? ORDER BY gm.user_id`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []GroupMember
+	for rows.Next() {
+		var m GroupMember
+		if err := rows.Scan(&m.UserID, &m.GroupID, &m.Role, &m.Username); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// IsLead reports whether userID leads at least one group.
+func (r *GroupRepo) IsLead(userID int64) (bool, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM group_members WHERE user_id = ? AND role = 'lead'", userID).Scan(&count)
+	return count > 0, err
+}
+
+// TeamMemberIDs returns the ids of every user in a group led by
+// leadUserID, excluding leadUserID itself.
+func (r *GroupRepo) TeamMemberIDs(leadUserID int64) ([]int64, error) {
+	rows, err := r.db.Query(
+		`SELECT DISTINCT gm.user_id
+		 FROM group_members gm
+		 WHERE gm.group_id IN (
+			SELECT group_id FROM group_members WHERE user_id = ? AND role = 'lead'
+		 ) AND gm.user_id != ?`, leadUserID, leadUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
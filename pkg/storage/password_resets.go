@@ -0,0 +1,51 @@
+package storage
+
+import "time"
+
+// PasswordReset is a pending self-service password-reset request.
+type PasswordReset struct {
+	TokenHash string
+	UserID    int64
+	ExpiresAt time.Time
+	Used      bool
+}
+
+// PasswordResetRepo provides typed access to the password_resets table.
+type PasswordResetRepo struct {
+	db *DB
+}
+
+// NewPasswordResetRepo returns a PasswordResetRepo backed by db.
+func NewPasswordResetRepo(db *DB) *PasswordResetRepo {
+	return &PasswordResetRepo{db: db}
+}
+
+// Create stores a new pending reset request. tokenHash must be the hash of
+// the token handed to the user, never the token itself.
+func (r *PasswordResetRepo) Create(tokenHash string, userID int64, expiresAt time.Time) error {
+	_, err := r.db.Exec(
+		"INSERT INTO password_resets (token_hash, user_id, expires_at, used) VALUES (?, ?, ?, 0)",
+		tokenHash, userID, expiresAt,
+	)
+	return err
+}
+
+// ByTokenHash looks up a reset request by its token hash. It returns
+// sql.ErrNoRows if none exists.
+func (r *PasswordResetRepo) ByTokenHash(tokenHash string) (*PasswordReset, error) {
+	var pr PasswordReset
+	err := r.db.QueryRow(
+		"SELECT token_hash, user_id, expires_at, used FROM password_resets WHERE token_hash = ?",
+		tokenHash,
+	).Scan(&pr.TokenHash, &pr.UserID, &pr.ExpiresAt, &pr.Used)
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MarkUsed flags a reset request as consumed so its token cannot be reused.
+func (r *PasswordResetRepo) MarkUsed(tokenHash string) error {
+	_, err := r.db.Exec("UPDATE password_resets SET used = 1 WHERE token_hash = ?", tokenHash)
+	return err
+}
@@ -0,0 +1,37 @@
+package storage
+
+// QuotaRepo provides typed access to the quotas table.
+type QuotaRepo struct {
+	db *DB
+}
+
+// NewQuotaRepo returns a QuotaRepo backed by db.
+func NewQuotaRepo(db *DB) *QuotaRepo {
+	return &QuotaRepo{db: db}
+}
+
+// ListByUser returns the year->quota map for userID.
+func (r *QuotaRepo) ListByUser(userID int64) (map[int]float64, error) {
+	rows, err := r.db.Query("SELECT year, quota FROM quotas WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int]float64{}
+	for rows.Next() {
+		var year int
+		var quota float64
+		if err := rows.Scan(&year, &quota); err != nil {
+			return nil, err
+		}
+		result[year] = quota
+	}
+	return result, rows.Err()
+}
+
+// Put upserts the quota for userID in the given year.
+func (r *QuotaRepo) Put(userID int64, year int, quota float64) error {
+	_, err := r.db.Exec("INSERT OR REPLACE INTO quotas (user_id, year, quota) VALUES (?, ?, ?)", userID, year, quota)
+	return err
+}
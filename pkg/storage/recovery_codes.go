@@ -0,0 +1,72 @@
+package storage
+
+// RecoveryCode is a single hashed, single-use 2FA recovery code.
+type RecoveryCode struct {
+	ID   int64
+	Hash string
+}
+
+// RecoveryCodeRepo provides typed access to the recovery_codes table.
+type RecoveryCodeRepo struct {
+	db *DB
+}
+
+// NewRecoveryCodeRepo returns a RecoveryCodeRepo backed by db.
+func NewRecoveryCodeRepo(db *DB) *RecoveryCodeRepo {
+	return &RecoveryCodeRepo{db: db}
+}
+
+// ReplaceAll deletes userID's existing recovery codes and inserts hashes as
+// the new set, in one transaction.
+func (r *RecoveryCodeRepo) ReplaceAll(userID int64, hashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM recovery_codes WHERE user_id = ?", userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO recovery_codes (user_id, code_hash) VALUES (?, ?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, hash := range hashes {
+		if _, err := stmt.Exec(userID, hash); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListUnused returns userID's recovery codes that have not yet been consumed.
+func (r *RecoveryCodeRepo) ListUnused(userID int64) ([]RecoveryCode, error) {
+	rows, err := r.db.Query("SELECT id, code_hash FROM recovery_codes WHERE user_id = ? AND used = 0", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []RecoveryCode
+	for rows.Next() {
+		var c RecoveryCode
+		if err := rows.Scan(&c.ID, &c.Hash); err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
+
+// MarkUsed flags a recovery code as consumed so it cannot be used again.
+func (r *RecoveryCodeRepo) MarkUsed(id int64) error {
+	_, err := r.db.Exec("UPDATE recovery_codes SET used = 1 WHERE id = ?", id)
+	return err
+}
@@ -0,0 +1,83 @@
+package storage
+
+import "time"
+
+// SessionRow mirrors a row of the sessions table, joined with the owning
+// user's username for convenience.
+type SessionRow struct {
+	Token     string
+	UserID    int64
+	Username  string
+	IsAdmin   bool
+	CreatedAt time.Time
+	LastSeen  time.Time
+	ExpiresAt time.Time
+}
+
+// SessionRepo provides typed access to the sessions table.
+type SessionRepo struct {
+	db *DB
+}
+
+// NewSessionRepo returns a SessionRepo backed by db.
+func NewSessionRepo(db *DB) *SessionRepo {
+	return &SessionRepo{db: db}
+}
+
+// Create persists a new session row.
+func (r *SessionRepo) Create(token string, userID int64, isAdmin bool, createdAt, lastSeen, expiresAt time.Time) error {
+	_, err := r.db.Exec(
+		"INSERT INTO sessions (token, user_id, is_admin, created_at, last_seen, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		token, userID, isAdmin, createdAt, lastSeen, expiresAt,
+	)
+	return err
+}
+
+// Touch updates last_seen and expires_at for an existing session.
+func (r *SessionRepo) Touch(token string, lastSeen, expiresAt time.Time) error {
+	_, err := r.db.Exec("UPDATE sessions SET last_seen = ?, expires_at = ? WHERE token = ?", lastSeen, expiresAt, token)
+	return err
+}
+
+// Delete removes a session row.
+func (r *SessionRepo) Delete(token string) error {
+	_, err := r.db.Exec("DELETE FROM sessions WHERE token = ?", token)
+	return err
+}
+
+// DeleteByUser removes every session belonging to userID.
+func (r *SessionRepo) DeleteByUser(userID int64) error {
+	_, err := r.db.Exec("DELETE FROM sessions WHERE user_id = ?", userID)
+	return err
+}
+
+// DeleteExpired removes all sessions that have expired as of now.
+func (r *SessionRepo) DeleteExpired(now time.Time) error {
+	_, err := r.db.Exec("DELETE FROM sessions WHERE expires_at <= ?", now)
+	return err
+}
+
+// LoadUnexpired returns all sessions that have not yet expired, joined with
+// the owning user's username.
+func (r *SessionRepo) LoadUnexpired(now time.Time) ([]SessionRow, error) {
+	rows, err := r.db.Query(
+		`SELECT s.token, s.user_id, u.username, s.is_admin, s.created_at, s.last_seen, s.expires_at
+		 FROM sessions s JOIN users u ON u.id = s.user_id
+		 WHERE s.expires_at > ?`,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []SessionRow
+	for rows.Next() {
+		var row SessionRow
+		if err := rows.Scan(&row.Token, &row.UserID, &row.Username, &row.IsAdmin, &row.CreatedAt, &row.LastSeen, &row.ExpiresAt); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
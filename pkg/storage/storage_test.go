@@ -0,0 +1,384 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestOpenMigratesIdempotentlyOnExistingFile guards against migrations that
+// only work against a fresh schema. :memory: DBs never re-run migrate()
+// against an existing file, so this opens the same on-disk database twice
+// to exercise the path that broke ALTER TABLE ... ADD COLUMN migrations
+// before they were made tolerant of re-runs.
+func TestOpenMigratesIdempotentlyOnExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open (first run): %v", err)
+	}
+	users := NewUserRepo(db)
+	userID, err := users.Create("mallory", "hash", "Mallory", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+	db.Close()
+
+	db, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open (second run against existing schema): %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	byID, err := NewUserRepo(db).ByID(userID)
+	if err != nil {
+		t.Fatalf("ByID after re-migrating: %v", err)
+	}
+	if byID.Username != "mallory" {
+		t.Errorf("ByID after re-migrating = %+v, want username mallory", byID)
+	}
+}
+
+func TestUserRepo(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+
+	cases := []struct {
+		name         string
+		username     string
+		displayName  string
+		state        string
+		defaultQuota float64
+		isAdmin      bool
+	}{
+		{"regular user", "alice", "Alice", "BY", 30, false},
+		{"admin user", "bob", "Bob", "NW", 25, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := users.Create(tc.username, "hash", tc.displayName, tc.state, tc.defaultQuota, tc.isAdmin)
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			byID, err := users.ByID(id)
+			if err != nil {
+				t.Fatalf("ByID: %v", err)
+			}
+			if byID.Username != tc.username || byID.IsAdmin != tc.isAdmin {
+				t.Errorf("ByID = %+v, want username %q isAdmin %v", byID, tc.username, tc.isAdmin)
+			}
+
+			byUsername, err := users.ByUsername(tc.username)
+			if err != nil {
+				t.Fatalf("ByUsername: %v", err)
+			}
+			if byUsername.ID != id {
+				t.Errorf("ByUsername.ID = %d, want %d", byUsername.ID, id)
+			}
+		})
+	}
+
+	count, err := users.Count()
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if count != len(cases) {
+		t.Errorf("Count = %d, want %d", count, len(cases))
+	}
+}
+
+func TestAbsenceRepoPutAndList(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+	absences := NewAbsenceRepo(db)
+
+	userID, err := users.Create("carol", "hash", "Carol", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	dates := map[string]string{
+		"2025-01-02": "UR",
+		"2025-06-15": "SUR",
+		"2024-12-24": "UUR",
+	}
+	count, err := absences.PutMany(userID, dates, "approved")
+	if err != nil {
+		t.Fatalf("PutMany: %v", err)
+	}
+	if count != len(dates) {
+		t.Errorf("PutMany count = %d, want %d", count, len(dates))
+	}
+
+	got, err := absences.ListByUser(userID, "2025")
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("ListByUser(2025) returned %d entries, want 2", len(got))
+	}
+	entry, ok := got["2025-01-02"]
+	if !ok || entry.Type != "UR" || entry.Status == nil || *entry.Status != "approved" {
+		t.Errorf("ListByUser(2025)[2025-01-02] = %+v, want type UR status approved", entry)
+	}
+
+	deleted, err := absences.DeleteMany(userID, []string{"2025-01-02"})
+	if err != nil {
+		t.Fatalf("DeleteMany: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("DeleteMany = %d, want 1", deleted)
+	}
+
+	got, err = absences.ListByUser(userID, "")
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("ListByUser(all) returned %d entries, want 2", len(got))
+	}
+}
+
+func TestQuotaRepo(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+	quotas := NewQuotaRepo(db)
+
+	userID, err := users.Create("dave", "hash", "Dave", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	if err := quotas.Put(userID, 2025, 28); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := quotas.Put(userID, 2025, 26); err != nil {
+		t.Fatalf("Put (overwrite): %v", err)
+	}
+
+	got, err := quotas.ListByUser(userID)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if got[2025] != 26 {
+		t.Errorf("ListByUser[2025] = %v, want 26", got[2025])
+	}
+}
+
+func TestSessionRepo(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+	sessions := NewSessionRepo(db)
+
+	userID, err := users.Create("erin", "hash", "Erin", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	expired := now.Add(-time.Hour)
+	if err := sessions.Create("tok-live", userID, false, now, now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("Create(live): %v", err)
+	}
+	if err := sessions.Create("tok-expired", userID, false, expired, expired, expired.Add(time.Minute)); err != nil {
+		t.Fatalf("Create(expired): %v", err)
+	}
+
+	rows, err := sessions.LoadUnexpired(now)
+	if err != nil {
+		t.Fatalf("LoadUnexpired: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Token != "tok-live" || rows[0].Username != "erin" {
+		t.Errorf("LoadUnexpired = %+v, want exactly tok-live for erin", rows)
+	}
+
+	newExpiry := now.Add(2 * time.Hour)
+	if err := sessions.Touch("tok-live", now, newExpiry); err != nil {
+		t.Fatalf("Touch: %v", err)
+	}
+	rows, err = sessions.LoadUnexpired(now)
+	if err != nil {
+		t.Fatalf("LoadUnexpired after Touch: %v", err)
+	}
+	if len(rows) != 1 || !rows[0].ExpiresAt.Equal(newExpiry) {
+		t.Errorf("ExpiresAt after Touch = %v, want %v", rows[0].ExpiresAt, newExpiry)
+	}
+
+	if err := sessions.DeleteExpired(now); err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+
+	if err := sessions.Delete("tok-live"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	rows, err = sessions.LoadUnexpired(now.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("LoadUnexpired after Delete: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("LoadUnexpired after Delete = %+v, want empty", rows)
+	}
+}
+
+func TestGroupRepo(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+	groups := NewGroupRepo(db)
+
+	leadID, err := users.Create("heidi", "hash", "Heidi", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create lead: %v", err)
+	}
+	memberID, err := users.Create("ivan", "hash", "Ivan", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create member: %v", err)
+	}
+	outsiderID, err := users.Create("judy", "hash", "Judy", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create outsider: %v", err)
+	}
+
+	groupID, err := groups.Create("Platform Team")
+	if err != nil {
+		t.Fatalf("Create group: %v", err)
+	}
+
+	if err := groups.AddMember(groupID, leadID, "lead"); err != nil {
+		t.Fatalf("AddMember(lead): %v", err)
+	}
+	if err := groups.AddMember(groupID, memberID, "member"); err != nil {
+		t.Fatalf("AddMember(member): %v", err)
+	}
+
+	isLead, err := groups.IsLead(leadID)
+	if err != nil {
+		t.Fatalf("IsLead: %v", err)
+	}
+	if !isLead {
+		t.Error("IsLead(leadID) = false, want true")
+	}
+	if isLead, err := groups.IsLead(memberID); err != nil || isLead {
+		t.Errorf("IsLead(memberID) = %v, %v, want false, nil", isLead, err)
+	}
+
+	teamIDs, err := groups.TeamMemberIDs(leadID)
+	if err != nil {
+		t.Fatalf("TeamMemberIDs: %v", err)
+	}
+	if len(teamIDs) != 1 || teamIDs[0] != memberID {
+		t.Errorf("TeamMemberIDs(leadID) = %v, want [%d]", teamIDs, memberID)
+	}
+
+	teamIDs, err = groups.TeamMemberIDs(outsiderID)
+	if err != nil {
+		t.Fatalf("TeamMemberIDs(outsider): %v", err)
+	}
+	if len(teamIDs) != 0 {
+		t.Errorf("TeamMemberIDs(outsiderID) = %v, want empty", teamIDs)
+	}
+
+	members, err := groups.MembersOf(groupID)
+	if err != nil {
+		t.Fatalf("MembersOf: %v", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("MembersOf returned %d members, want 2", len(members))
+	}
+
+	if err := groups.RemoveMember(groupID, memberID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	teamIDs, err = groups.TeamMemberIDs(leadID)
+	if err != nil {
+		t.Fatalf("TeamMemberIDs after RemoveMember: %v", err)
+	}
+	if len(teamIDs) != 0 {
+		t.Errorf("TeamMemberIDs(leadID) after RemoveMember = %v, want empty", teamIDs)
+	}
+}
+
+func TestPasswordResetRepo(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+	resets := NewPasswordResetRepo(db)
+
+	userID, err := users.Create("kim", "hash", "Kim", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	if err := resets.Create("hash-of-token", userID, now.Add(time.Hour)); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := resets.ByTokenHash("hash-of-token")
+	if err != nil {
+		t.Fatalf("ByTokenHash: %v", err)
+	}
+	if got.UserID != userID || got.Used {
+		t.Errorf("ByTokenHash = %+v, want UserID %d Used false", got, userID)
+	}
+
+	if err := resets.MarkUsed("hash-of-token"); err != nil {
+		t.Fatalf("MarkUsed: %v", err)
+	}
+	got, err = resets.ByTokenHash("hash-of-token")
+	if err != nil {
+		t.Fatalf("ByTokenHash after MarkUsed: %v", err)
+	}
+	if !got.Used {
+		t.Error("ByTokenHash after MarkUsed = Used false, want true")
+	}
+
+	if _, err := resets.ByTokenHash("does-not-exist"); err != sql.ErrNoRows {
+		t.Errorf("ByTokenHash(unknown) error = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestUserRepoCalendarToken(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepo(db)
+
+	userID, err := users.Create("liam", "hash", "Liam", "BY", 30, false)
+	if err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	if _, err := users.ByCalendarToken("tok-1"); err != sql.ErrNoRows {
+		t.Errorf("ByCalendarToken before it's set error = %v, want sql.ErrNoRows", err)
+	}
+
+	if err := users.SetCalendarToken(userID, "tok-1"); err != nil {
+		t.Fatalf("SetCalendarToken: %v", err)
+	}
+
+	got, err := users.ByCalendarToken("tok-1")
+	if err != nil {
+		t.Fatalf("ByCalendarToken: %v", err)
+	}
+	if got.ID != userID {
+		t.Errorf("ByCalendarToken(tok-1).ID = %d, want %d", got.ID, userID)
+	}
+
+	if err := users.SetCalendarToken(userID, "tok-2"); err != nil {
+		t.Fatalf("SetCalendarToken (rotate): %v", err)
+	}
+	if _, err := users.ByCalendarToken("tok-1"); err != sql.ErrNoRows {
+		t.Errorf("ByCalendarToken(tok-1) after rotation error = %v, want sql.ErrNoRows", err)
+	}
+}
@@ -0,0 +1,171 @@
+package storage
+
+import "database/sql"
+
+// User mirrors a row of the users table.
+type User struct {
+	ID            int64
+	Username      string
+	PasswordHash  string
+	DisplayName   string
+	State         string
+	DefaultQuota  float64
+	IsAdmin       bool
+	CreatedAt     string
+	TOTPSecret    sql.NullString
+	TOTPEnabled   bool
+	CalendarToken sql.NullString
+}
+
+// UserRepo provides typed access to the users table.
+type UserRepo struct {
+	db *DB
+}
+
+// NewUserRepo returns a UserRepo backed by db.
+func NewUserRepo(db *DB) *UserRepo {
+	return &UserRepo{db: db}
+}
+
+// Count returns the total number of users.
+func (r *UserRepo) Count() (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	return count, err
+}
+
+// Create inserts a new user and returns its id.
+func (r *UserRepo) Create(username, passwordHash, displayName, state string, defaultQuota float64, isAdmin bool) (int64, error) {
+	res, err := r.db.Exec(
+		"INSERT INTO users (username, password_hash, display_name, state, default_quota, is_admin) VALUES (?, ?, ?, ?, ?, ?)",
+		username, passwordHash, displayName, state, defaultQuota, isAdmin,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// ByUsername looks up a user by username. It returns sql.ErrNoRows if none exists.
+func (r *UserRepo) ByUsername(username string) (*User, error) {
+	var u User
+	err := r.db.QueryRow(
+		"SELECT id, username, password_hash, display_name, state, default_quota, is_admin, created_at, totp_secret, totp_enabled, calendar_token FROM users WHERE username = ?",
+		username,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, &u.DisplayName, &u.State, &u.DefaultQuota, &u.IsAdmin, &u.CreatedAt, &u.TOTPSecret, &u.TOTPEnabled, &u.CalendarToken)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// ByID looks up a user by id. It returns sql.ErrNoRows if none exists.
+func (r *UserRepo) ByID(id int64) (*User, error) {
+	var u User
+	err := r.db.QueryRow(
+		"SELECT id, username, password_hash, display_name, state, default_quota, is_admin, created_at, totp_secret, totp_enabled, calendar_token FROM users WHERE id = ?",
+		id,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, &u.DisplayName, &u.State, &u.DefaultQuota, &u.IsAdmin, &u.CreatedAt, &u.TOTPSecret, &u.TOTPEnabled, &u.CalendarToken)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// ByCalendarToken looks up a user by their iCalendar feed token. It returns
+// sql.ErrNoRows if no user has that token (including if it was never set).
+func (r *UserRepo) ByCalendarToken(token string) (*User, error) {
+	var u User
+	err := r.db.QueryRow(
+		"SELECT id, username, password_hash, display_name, state, default_quota, is_admin, created_at, totp_secret, totp_enabled, calendar_token FROM users WHERE calendar_token = ?",
+		token,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, &u.DisplayName, &u.State, &u.DefaultQuota, &u.IsAdmin, &u.CreatedAt, &u.TOTPSecret, &u.TOTPEnabled, &u.CalendarToken)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// List returns all users ordered by id.
+func (r *UserRepo) List() ([]User, error) {
+	rows, err := r.db.Query("SELECT id, username, display_name, state, default_quota, is_admin, created_at FROM users ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.ID, &u.Username, &u.DisplayName, &u.State, &u.DefaultQuota, &u.IsAdmin, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// UpdateSettings updates the fields that are non-nil.
+func (r *UserRepo) UpdateSettings(id int64, state *string, defaultQuota *float64, displayName *string) error {
+	if state != nil {
+		if _, err := r.db.Exec("UPDATE users SET state = ? WHERE id = ?", *state, id); err != nil {
+			return err
+		}
+	}
+	if defaultQuota != nil {
+		if _, err := r.db.Exec("UPDATE users SET default_quota = ? WHERE id = ?", *defaultQuota, id); err != nil {
+			return err
+		}
+	}
+	if displayName != nil {
+		if _, err := r.db.Exec("UPDATE users SET display_name = ? WHERE id = ?", *displayName, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdatePassword sets a new password hash for the given user.
+func (r *UserRepo) UpdatePassword(id int64, passwordHash string) error {
+	_, err := r.db.Exec("UPDATE users SET password_hash = ? WHERE id = ?", passwordHash, id)
+	return err
+}
+
+// SetTOTPSecret stores an unconfirmed TOTP secret for id. It does not enable
+// 2FA by itself; EnableTOTP does that once the secret has been confirmed.
+func (r *UserRepo) SetTOTPSecret(id int64, secret string) error {
+	_, err := r.db.Exec("UPDATE users SET totp_secret = ? WHERE id = ?", secret, id)
+	return err
+}
+
+// EnableTOTP turns on 2FA for id. Call it only after the pending secret has
+// been confirmed with a valid code.
+func (r *UserRepo) EnableTOTP(id int64) error {
+	_, err := r.db.Exec("UPDATE users SET totp_enabled = 1 WHERE id = ?", id)
+	return err
+}
+
+// DisableTOTP turns off 2FA for id and discards its secret.
+func (r *UserRepo) DisableTOTP(id int64) error {
+	_, err := r.db.Exec("UPDATE users SET totp_secret = NULL, totp_enabled = 0 WHERE id = ?", id)
+	return err
+}
+
+// SetCalendarToken stores token as id's iCalendar feed token, replacing any
+// previous one so old subscription URLs stop working.
+func (r *UserRepo) SetCalendarToken(id int64, token string) error {
+	_, err := r.db.Exec("UPDATE users SET calendar_token = ? WHERE id = ?", token, id)
+	return err
+}
+
+// Delete removes a user and its absences/quotas.
+func (r *UserRepo) Delete(id int64) error {
+	if _, err := r.db.Exec("DELETE FROM absences WHERE user_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec("DELETE FROM quotas WHERE user_id = ?", id); err != nil {
+		return err
+	}
+	_, err := r.db.Exec("DELETE FROM users WHERE id = ?", id)
+	return err
+}
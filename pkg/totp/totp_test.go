@@ -0,0 +1,74 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestGenerateCodeRFC4226Vectors checks generateCode against the reference
+// HOTP values from RFC 4226 Appendix D, computed over the ASCII secret
+// "12345678901234567890" (encoded here as base32, since generateCode always
+// expects a base32 secret).
+func TestGenerateCodeRFC4226Vectors(t *testing.T) {
+	secret := base32Enc.EncodeToString([]byte("12345678901234567890"))
+
+	want := map[uint64]string{
+		0: "755224",
+		1: "287082",
+		2: "359152",
+		3: "969429",
+		4: "338314",
+		5: "254676",
+		6: "287922",
+		7: "162583",
+		8: "399871",
+		9: "520489",
+	}
+
+	for counter, wantCode := range want {
+		got, err := generateCode(secret, counter)
+		if err != nil {
+			t.Fatalf("generateCode(%d): %v", counter, err)
+		}
+		if got != wantCode {
+			t.Errorf("generateCode(%d) = %q, want %q", counter, got, wantCode)
+		}
+	}
+}
+
+func TestValidateAcceptsCurrentCodeWithinSkew(t *testing.T) {
+	secret := GenerateSecret()
+	counter := uint64(time.Now().Unix() / int64(period.Seconds()))
+
+	code, err := generateCode(secret, counter)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	if !Validate(secret, code, 1) {
+		t.Error("Validate rejected the current code")
+	}
+}
+
+func TestValidateRejectsStaleCode(t *testing.T) {
+	secret := GenerateSecret()
+	counter := uint64(time.Now().Unix()/int64(period.Seconds())) - 100
+
+	stale, err := generateCode(secret, counter)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	if Validate(secret, stale, 1) {
+		t.Error("Validate accepted a code far outside the skew window")
+	}
+}
+
+func TestURIContainsAccountAndIssuer(t *testing.T) {
+	uri := URI("Urlaubsplaner", "alice", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/") {
+		t.Errorf("URI = %q, want otpauth://totp/ prefix", uri)
+	}
+	if !strings.Contains(uri, "Urlaubsplaner") || !strings.Contains(uri, "alice") {
+		t.Errorf("URI = %q, want it to reference the issuer and account", uri)
+	}
+}